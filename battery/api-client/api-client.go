@@ -10,24 +10,35 @@ import (
 	"gok-pi/internal/lib/sl"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	maxRetry     = 5
-	retryStep    = 3
-	opModeAuto   = "2"
-	opModeManual = "1"
+	maxRetry = 5
+	// readings further apart are not integrated into daily energy
+	maxStatsGap = 5 * time.Minute
 )
 
-var httpClient = &http.Client{}
+var (
+	httpClient      = &http.Client{}
+	ErrNotSupported = errors.New("operation not supported by the battery API")
+	// retryStep is the delay added before each further retry; tests shorten it
+	retryStep = 3 * time.Second
+)
 
 type ApiClient struct {
-	url   string
-	token string
-	log   *slog.Logger
+	url         string
+	token       string
+	daily       entity.DailyBatteryStats
+	lastRead    time.Time
+	discharging bool
+	mutex       sync.Mutex
+	log         *slog.Logger
 }
 
 func New(url, token string, log *slog.Logger) *ApiClient {
@@ -51,9 +62,60 @@ func (c *ApiClient) Status() (*entity.SystemStatus, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parsing status: %w", err)
 	}
+	c.observeDaily(status)
 	return status, nil
 }
 
+// DailyStats returns the statistics of the current day. The battery API has no daily statistics,
+// so they are collected from the status readings made through this client.
+func (c *ApiClient) DailyStats() (*entity.DailyBatteryStats, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.daily.Date.IsZero() {
+		return nil, fmt.Errorf("no status readings yet")
+	}
+	stats := c.daily
+	return &stats, nil
+}
+
+func (c *ApiClient) observeDaily(status *entity.SystemStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	elapsed := time.Since(c.lastRead)
+	now := time.Now()
+	if c.lastRead.IsZero() || elapsed > maxStatsGap {
+		elapsed = 0
+	}
+	c.daily.Observe(status, now, elapsed, c.discharging)
+	c.lastRead = now
+	c.discharging = status.BatteryDischarging
+}
+
+func (c *ApiClient) EnergyMeters() (*entity.EnergyMeterSnapshot, error) {
+	body, err := c.requestWithRetry(http.MethodGet, nil, c.url, "powermeter")
+	if err != nil {
+		return nil, err
+	}
+	meters, err := entity.ParsePowerMeters(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing power meters: %w", err)
+	}
+	return meters, nil
+}
+
+// BatteryInfo reads the battery module data reported by the BMS.
+func (c *ApiClient) BatteryInfo() (*entity.BatteryInfo, error) {
+	body, err := c.requestWithRetry(http.MethodGet, nil, c.url, "battery")
+	if err != nil {
+		return nil, err
+	}
+	info, err := entity.ParseBatteryInfo(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing battery info: %w", err)
+	}
+	return info, nil
+}
+
 func (c *ApiClient) StartDischarge(power int) error {
 	_, err := c.requestWithRetry(http.MethodPost, nil, c.url, "setpoint", "discharge", fmt.Sprintf("%d", power))
 	return err
@@ -64,22 +126,45 @@ func (c *ApiClient) StopDischarge() error {
 	return err
 }
 
-// SwitchOperatingModeToManual switches the operating mode of the API client to manual.
-// It returns nil if the current mode is already set to manual, otherwise it sends a request to change the operating mode to manual.
-func (c *ApiClient) SwitchOperatingModeToManual(currentMode string) error {
-	if currentMode == opModeManual {
-		return nil
-	}
-	return c.doRequestChangeConfig("EM_OperatingMode", opModeManual)
+// StartCharge sets a grid charging setpoint; like discharge, it takes effect in manual mode only.
+func (c *ApiClient) StartCharge(power int) error {
+	_, err := c.requestWithRetry(http.MethodPost, nil, c.url, "setpoint", "charge", fmt.Sprintf("%d", power))
+	return err
+}
+
+func (c *ApiClient) StopCharge() error {
+	_, err := c.requestWithRetry(http.MethodPost, nil, c.url, "setpoint", "charge", "0")
+	return err
+}
+
+// SetOperatingMode sends a request to change the operating mode of the inverter.
+func (c *ApiClient) SetOperatingMode(mode entity.OperatingMode) error {
+	return c.doRequestChangeConfig("EM_OperatingMode", string(mode))
 }
 
-// SwitchOperatingModeToAuto switches the operating mode of the API client to automatic.
-// It sends a request to change the operating mode to automatic.
-func (c *ApiClient) SwitchOperatingModeToAuto(currentMode string) error {
-	if currentMode == opModeAuto {
-		return nil
+// Reset would soft reset the BMS; the battery API has no reset command, so it always returns ErrNotSupported.
+func (c *ApiClient) Reset() error {
+	return ErrNotSupported
+}
+
+// Ping opens and closes a TCP connection to the battery API host, which is much quicker than a status request.
+func (c *ApiClient) Ping() error {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return fmt.Errorf("parsing url: %w", err)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
 	}
-	return c.doRequestChangeConfig("EM_OperatingMode", opModeAuto)
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(u.Hostname(), port), 5*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
 }
 
 func (c *ApiClient) fullPath(params ...string) string {
@@ -110,17 +195,19 @@ func (c *ApiClient) requestWithRetry(method string, data interface{}, params ...
 		}
 	}
 
+	var err error
 	for i := 0; i < maxRetry; i++ {
-		responseBody, err := c.doRequest(method, path, bytes.NewReader(body))
+		var responseBody []byte
+		responseBody, err = c.doRequest(method, path, bytes.NewReader(body))
 		if err == nil {
 			return responseBody, nil
 		}
 		log.With(
 			slog.Int("attempt", i+1),
 		).Debug("retrying request")
-		time.Sleep(time.Duration((i+1)*retryStep) * time.Second)
+		time.Sleep(time.Duration(i+1) * retryStep)
 	}
-	return nil, fmt.Errorf("request failed after %d retries", maxRetry)
+	return nil, fmt.Errorf("request failed after %d retries: %w", maxRetry, err)
 }
 
 func (c *ApiClient) doRequest(method, url string, reader io.Reader) ([]byte, error) {
@@ -153,7 +240,7 @@ func (c *ApiClient) doRequest(method, url string, reader io.Reader) ([]byte, err
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			err = fmt.Errorf("request timeout")
+			err = fmt.Errorf("request timeout: %w", err)
 		}
 		return nil, err
 	}
@@ -214,7 +301,7 @@ func (c *ApiClient) doRequestChangeConfig(parameter, value string) error {
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			err = fmt.Errorf("request timeout")
+			err = fmt.Errorf("request timeout: %w", err)
 		}
 		return err
 	}
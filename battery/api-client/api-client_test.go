@@ -0,0 +1,161 @@
+package apiclient
+
+import (
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/testutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testToken = "recorded-token"
+
+// recording is a response of the battery API recorded in testdata.
+type recording struct {
+	status int
+	file   string
+}
+
+// replay serves the recordings by path below /api/v2 and counts the requests of each path.
+func replay(t *testing.T, recordings map[string]recording) (*ApiClient, map[string]int) {
+	t.Helper()
+	retryStep = 0
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if token := r.Header.Get("Auth-Token"); token != testToken {
+			t.Errorf("%s: auth token %q, want %q", r.URL.Path, token, testToken)
+		}
+		rec, ok := recordings[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := os.ReadFile(filepath.Join("testdata", rec.file))
+		if err != nil {
+			t.Errorf("reading recording: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return New(server.URL+"/api/v2", testToken, testutil.NewTestLogger(t)), requests
+}
+
+func TestStatus(t *testing.T) {
+	client, _ := replay(t, map[string]recording{
+		"/api/v2/status": {http.StatusOK, "status.json"},
+	})
+	status, err := client.Status()
+	if err != nil {
+		t.Fatalf("reading status: %v", err)
+	}
+	if status.RSOC != 64 || status.USOC != 61 || status.RemainingCapacityWh != 6442 {
+		t.Errorf("charge: RSOC %v, USOC %v, remaining %v Wh", status.RSOC, status.USOC, status.RemainingCapacityWh)
+	}
+	if status.PacTotalW != 2095 || status.ConsumptionW != 2101 || status.GridFeedInW != -6 {
+		t.Errorf("power: pac %v W, consumption %v W, feed-in %v W", status.PacTotalW, status.ConsumptionW, status.GridFeedInW)
+	}
+	if !status.DischargeActive() || status.OperatingMode != "2" || status.SystemStatus != "OnGrid" {
+		t.Errorf("state: discharging %v, mode %q, system %q", status.DischargeActive(), status.OperatingMode, status.SystemStatus)
+	}
+	if status.Sac2 != nil || status.Sac3 != nil {
+		t.Errorf("Sac2 %v, Sac3 %v, want null phases", status.Sac2, status.Sac3)
+	}
+	if _, err = client.DailyStats(); err != nil {
+		t.Errorf("daily stats after a reading: %v", err)
+	}
+}
+
+func TestBatteryInfo(t *testing.T) {
+	client, _ := replay(t, map[string]recording{
+		"/api/v2/battery": {http.StatusOK, "battery.json"},
+	})
+	info, err := client.BatteryInfo()
+	if err != nil {
+		t.Fatalf("reading battery info: %v", err)
+	}
+	testutil.AssertBatteryInfoEqual(t, entity.BatteryInfo{
+		ChargeCurrentLimit:       39.97,
+		CycleCount:               652,
+		DischargeCurrentLimit:    39.97,
+		FullChargeCapacity:       201.98,
+		FullChargeCapacityWh:     10277.03,
+		MaximumCellTemperature:   23.95,
+		MaximumCellVoltage:       3.32,
+		MaximumCellVoltageNum:    3,
+		MaximumModuleCurrent:     -1.1,
+		MaximumModuleDcVoltage:   53.15,
+		MaximumModuleTemperature: 23.7,
+		MinimumCellTemperature:   22.75,
+		MinimumCellVoltage:       3.31,
+		MinimumCellVoltageNum:    12,
+		MinimumModuleCurrent:     -1.15,
+		MinimumModuleDcVoltage:   53.1,
+		MinimumModuleTemperature: 22.9,
+		NominalModuleDcVoltage:   51.2,
+		RelativeStateOfCharge:    64,
+		RemainingCapacity:        128.91,
+		SystemCurrent:            -9.96,
+		SystemDcVoltage:          209.22,
+		SystemStatus:             49,
+		UsableRemainingCapacity:  122.77,
+	}, *info)
+}
+
+func TestEnergyMeters(t *testing.T) {
+	client, _ := replay(t, map[string]recording{
+		"/api/v2/powermeter": {http.StatusOK, "powermeter.json"},
+	})
+	meters, err := client.EnergyMeters()
+	if err != nil {
+		t.Fatalf("reading energy meters: %v", err)
+	}
+	if meters.SolarGeneratedKwh != 6842.4 || meters.GridImportKwh != 4470.2 || meters.GridExportKwh != 3120.5 {
+		t.Errorf("meters %+v", meters)
+	}
+	if math.Abs(meters.SelfConsumedKwh-3721.9) > 1e-6 {
+		t.Errorf("self consumed %v kWh, want generation less export", meters.SelfConsumedKwh)
+	}
+}
+
+func TestServiceUnavailable(t *testing.T) {
+	client, requests := replay(t, map[string]recording{
+		"/api/v2/status": {http.StatusServiceUnavailable, "service-unavailable.html"},
+	})
+	if _, err := client.Status(); err == nil {
+		t.Fatal("expected an error for status 503")
+	}
+	if n := requests["/api/v2/status"]; n != maxRetry {
+		t.Errorf("%d requests, want %d", n, maxRetry)
+	}
+	if _, err := client.DailyStats(); err == nil {
+		t.Error("expected no daily stats without a reading")
+	}
+}
+
+func TestMalformedStatus(t *testing.T) {
+	client, requests := replay(t, map[string]recording{
+		"/api/v2/status": {http.StatusOK, "status-truncated.json"},
+	})
+	if _, err := client.Status(); err == nil {
+		t.Fatal("expected an error for a truncated body")
+	}
+	// the body was delivered, so the request is not retried
+	if n := requests["/api/v2/status"]; n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+}
+
+func TestResetNotSupported(t *testing.T) {
+	client, requests := replay(t, nil)
+	testutil.AssertErrorIs(t, client.Reset(), ErrNotSupported)
+	if len(requests) != 0 {
+		t.Errorf("requests %v, want none", requests)
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"gok-pi/battery/api/auth"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// EnableAdmin registers the privileged endpoints; requests must carry the token as a bearer token,
+// unless JWT authentication is enabled.
+func (s *Server) EnableAdmin(token string) {
+	s.admin = token
+}
+
+// requireAdmin rejects requests without the admin token. With JWT authentication, the token carries
+// the admin role instead, which is checked before.
+func (s *Server) requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.jwtKey != nil {
+			next(w, r)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.admin)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+type auditKey struct{}
+
+// auditRecord collects what the audit entry of a request reports besides the response status.
+type auditRecord struct {
+	user string
+	err  error
+}
+
+// statusRecorder captures the response status for the audit entry.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// audited writes one audit log entry for every request to an admin endpoint, once it is handled:
+// rejected by JWT authentication, the role check or the admin token, failed, or succeeded.
+// The caller is identified by remote address and, with JWT, by user ID.
+func (s *Server) audited(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		record := &auditRecord{}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), auditKey{}, record)))
+
+		log := s.log.With(
+			slog.String("audit", r.Method+" "+r.URL.Path),
+			slog.String("remote", r.RemoteAddr),
+			slog.String("user_agent", r.UserAgent()),
+			slog.Int("status", recorder.status),
+		)
+		if record.user != "" {
+			log = log.With(slog.String("user", record.user))
+		}
+		switch {
+		case recorder.status == http.StatusUnauthorized || recorder.status == http.StatusForbidden:
+			log.Warn("admin request rejected")
+		case record.err != nil:
+			log.With(sl.Err(record.err)).Error("admin request failed")
+		case recorder.status >= http.StatusBadRequest:
+			log.Warn("admin request failed")
+		default:
+			log.Warn("admin request succeeded")
+		}
+	})
+}
+
+// auditUser records the user authenticated by the JWT middleware in the audit entry; it runs before
+// the role check, so rejected users are identified as well.
+func auditUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := auth.FromContext(r.Context())
+		record, audited := r.Context().Value(auditKey{}).(*auditRecord)
+		if ok && audited {
+			record.user = claims.Subject
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// auditError records the error of a failed admin request in its audit entry.
+func auditError(r *http.Request, err error) {
+	if record, ok := r.Context().Value(auditKey{}).(*auditRecord); ok {
+		record.err = err
+	}
+}
+
+func (s *Server) handleReset(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mutex.RLock()
+	b, ok := s.batteries[name]
+	s.mutex.RUnlock()
+	if !ok {
+		http.Error(w, "battery not found", http.StatusNotFound)
+		return
+	}
+
+	if err := b.Reset(); err != nil {
+		auditError(r, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
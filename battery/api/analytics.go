@@ -0,0 +1,34 @@
+package api
+
+import (
+	"gok-pi/battery/entity"
+	"gok-pi/battery/storage"
+	"gok-pi/internal/lib/sl"
+	"net/http"
+	"time"
+)
+
+type weeklyComparison struct {
+	ThisWeek entity.WeeklyStats `json:"thisWeek"`
+	LastWeek entity.WeeklyStats `json:"lastWeek"`
+}
+
+// handleWeeklyComparison returns session totals of the current week, so far, and of the previous week.
+// Weeks start on Monday in local time.
+func (s *Server) handleWeeklyComparison(w http.ResponseWriter, r *http.Request) {
+	thisWeekStart := storage.WeekStart(time.Now())
+
+	thisWeek, err := storage.QueryWeeklyAggregate(r.Context(), s.store, thisWeekStart)
+	if err != nil {
+		s.log.Error("querying this week", sl.Err(err))
+		http.Error(w, "querying sessions failed", http.StatusBadGateway)
+		return
+	}
+	lastWeek, err := storage.QueryWeeklyAggregate(r.Context(), s.store, thisWeekStart.AddDate(0, 0, -7))
+	if err != nil {
+		s.log.Error("querying last week", sl.Err(err))
+		http.Error(w, "querying sessions failed", http.StatusBadGateway)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, weeklyComparison{ThisWeek: thisWeek, LastWeek: lastWeek})
+}
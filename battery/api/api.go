@@ -0,0 +1,235 @@
+package api
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"gok-pi/battery/api/auth"
+	"gok-pi/battery/api/openapi"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/battery/load"
+	"gok-pi/battery/storage"
+	"gok-pi/battery/tariff"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Battery is the part of a discharge worker exposed over the API.
+type Battery interface {
+	Name() string
+	State() discharger.State
+	LastSession() *entity.SessionSummary
+	RecentSnapshots() []entity.BatterySnapshot
+	ForceStart() error
+	ForceStop() error
+	Reset() error
+}
+
+type Server struct {
+	batteries map[string]Battery
+	scheduler *load.ApplianceScheduler
+	tariff    tariff.TariffSource
+	store     storage.EventStore
+	admin     string
+	jwtKey    *rsa.PublicKey
+	mutex     sync.RWMutex
+	log       *slog.Logger
+}
+
+func New(log *slog.Logger) *Server {
+	return &Server{
+		batteries: make(map[string]Battery),
+		log:       log.With(sl.Module("api")),
+	}
+}
+
+func (s *Server) Register(battery Battery) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.batteries[battery.Name()] = battery
+}
+
+// Deregister removes the battery of name, so it is no longer listed or controlled.
+func (s *Server) Deregister(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.batteries, name)
+}
+
+// EnableJWT requires every API request to carry a JWT signed with the private key matching key.
+func (s *Server) EnableJWT(key *rsa.PublicKey) {
+	s.jwtKey = key
+}
+
+// EnableApplianceScheduling registers the appliance scheduling endpoint, planning runs against the tariff.
+func (s *Server) EnableApplianceScheduling(scheduler *load.ApplianceScheduler, tariff tariff.TariffSource) {
+	s.scheduler = scheduler
+	s.tariff = tariff
+}
+
+// route is an endpoint with the description used to generate the OpenAPI specification.
+// Endpoints of optional features are only registered when enabled. With JWT authentication,
+// the token must grant at least role.
+type route struct {
+	openapi.Route
+	handler http.HandlerFunc
+	enabled bool
+	role    auth.Role
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	var routes []route
+	for _, r := range s.routes() {
+		if !r.enabled {
+			continue
+		}
+		var handler http.Handler = r.handler
+		if s.jwtKey != nil {
+			handler = auth.RequireRole(r.role)(handler)
+			if r.role == auth.Admin {
+				handler = auditUser(handler)
+			}
+			handler = auth.Middleware(s.jwtKey)(handler)
+		}
+		// requests to admin endpoints are audited, including those rejected by authentication
+		if r.role == auth.Admin {
+			handler = s.audited(handler)
+		}
+		mux.Handle(r.Method+" "+r.Path, handler)
+		routes = append(routes, r)
+	}
+	s.registerDocs(mux, routes)
+	return mux
+}
+
+// routes lists all endpoints of the API.
+func (s *Server) routes() []route {
+	batteryParam := openapi.QueryParam("battery", "name of the battery; all batteries if omitted")
+	return []route{
+		{openapi.Route{Method: http.MethodGet, Path: "/status", OperationID: "GetStatus",
+			Summary: "State of all batteries", Response: []discharger.State{}},
+			s.handleStatus, true, auth.Viewer},
+		{openapi.Route{Method: http.MethodPost, Path: "/discharge/start", OperationID: "StartDischarge",
+			Summary: "Force discharge", Query: []*openapi.Parameter{batteryParam}, Status: http.StatusAccepted},
+			s.handleStart, true, auth.Operator},
+		{openapi.Route{Method: http.MethodPost, Path: "/discharge/stop", OperationID: "StopDischarge",
+			Summary: "Force stop of discharge", Query: []*openapi.Parameter{batteryParam}, Status: http.StatusAccepted},
+			s.handleStop, true, auth.Operator},
+		{openapi.Route{Method: http.MethodGet, Path: "/api/v1/sessions", OperationID: "GetSessions",
+			Summary: "Last completed session of each battery", Response: []entity.SessionSummary{}},
+			s.handleSessions, true, auth.Viewer},
+		{openapi.Route{Method: http.MethodPost, Path: "/api/v1/schedule-appliance", OperationID: "ScheduleAppliance",
+			Summary: "Cheapest start time for an appliance run", Request: scheduleApplianceRequest{},
+			Response: scheduleApplianceResponse{}},
+			s.handleScheduleAppliance, s.scheduler != nil, auth.Operator},
+		{openapi.Route{Method: http.MethodPost, Path: "/api/v1/batteries/{name}/reset", OperationID: "ResetBattery",
+			Summary: "Soft reset of the BMS", Status: http.StatusAccepted, Security: true},
+			s.requireAdmin(s.handleReset), s.admin != "" || s.jwtKey != nil, auth.Admin},
+		{openapi.Route{Method: http.MethodGet, Path: "/api/v1/batteries/{name}/history", OperationID: "GetHistory",
+			Summary: "SoC history", Query: []*openapi.Parameter{
+				openapi.QueryParam("start", "RFC 3339 start time; 24 hours before end by default"),
+				openapi.QueryParam("end", "RFC 3339 end time; now by default"),
+				openapi.QueryParam("step", "averaging interval as a Go duration; 1h by default"),
+			}, Response: []historyPoint{}},
+			s.handleHistory, true, auth.Viewer},
+		{openapi.Route{Method: http.MethodGet, Path: "/api/v1/analytics/weekly-comparison", OperationID: "GetWeeklyComparison",
+			Summary: "Session totals of this week and last week", Response: weeklyComparison{}},
+			s.handleWeeklyComparison, s.store != nil, auth.Viewer},
+	}
+}
+
+func (s *Server) Listen(ip, port string) error {
+	address := ip + ":" + port
+	return http.ListenAndServe(address, s.Handler())
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	states := make([]discharger.State, 0)
+	for _, b := range s.list() {
+		states = append(states, b.State())
+	}
+	s.writeJSON(w, http.StatusOK, states)
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, _ *http.Request) {
+	sessions := make([]entity.SessionSummary, 0)
+	for _, b := range s.list() {
+		if session := b.LastSession(); session != nil {
+			sessions = append(sessions, *session)
+		}
+	}
+	s.writeJSON(w, http.StatusOK, sessions)
+}
+
+// handleStart forces discharge of the battery named in the "battery" query parameter, or of all batteries.
+func (s *Server) handleStart(w http.ResponseWriter, r *http.Request) {
+	batteries, ok := s.selected(w, r)
+	if !ok {
+		return
+	}
+	for _, b := range batteries {
+		if err := b.ForceStart(); err != nil {
+			s.log.With(slog.String("battery", b.Name()), sl.Err(err)).Error("forcing discharge start")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	s.log.With(slog.Int("batteries", len(batteries))).Info("discharge start requested")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
+	batteries, ok := s.selected(w, r)
+	if !ok {
+		return
+	}
+	for _, b := range batteries {
+		if err := b.ForceStop(); err != nil {
+			s.log.With(slog.String("battery", b.Name()), sl.Err(err)).Error("forcing discharge stop")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	s.log.With(slog.Int("batteries", len(batteries))).Info("discharge stop requested")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) selected(w http.ResponseWriter, r *http.Request) ([]Battery, bool) {
+	name := r.URL.Query().Get("battery")
+	if name == "" {
+		return s.list(), true
+	}
+	s.mutex.RLock()
+	b, ok := s.batteries[name]
+	s.mutex.RUnlock()
+	if !ok {
+		http.Error(w, "battery not found", http.StatusNotFound)
+		return nil, false
+	}
+	return []Battery{b}, true
+}
+
+func (s *Server) list() []Battery {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	batteries := make([]Battery, 0, len(s.batteries))
+	for _, b := range s.batteries {
+		batteries = append(batteries, b)
+	}
+	sort.Slice(batteries, func(i, j int) bool {
+		return batteries[i].Name() < batteries[j].Name()
+	})
+	return batteries
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.log.Error("writing response", sl.Err(err))
+	}
+}
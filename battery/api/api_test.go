@@ -0,0 +1,165 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"gok-pi/battery/api"
+	"gok-pi/battery/client/mock"
+	"gok-pi/battery/client/mock/mocktest"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/testutil"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testBattery = "home"
+
+// newTestServer serves the API for one discharge worker running against a mock client. The worker's
+// window has already passed today, so it only discharges when forced.
+func newTestServer(t *testing.T) (*httptest.Server, *mock.MockClient) {
+	t.Helper()
+	log := testutil.NewTestLogger(t)
+
+	client := mock.New()
+	client.SetStatusSequence([]entity.SystemStatus{{
+		OperatingMode:       string(entity.Automatic),
+		RSOC:                80,
+		RemainingCapacityWh: 8000,
+	}})
+
+	worker, err := discharger.New(testBattery, client, log)
+	if err != nil {
+		t.Fatalf("creating worker: %v", err)
+	}
+	now := time.Now()
+	worker.SetTime(now.Add(-2*time.Hour).Format("15:04"), now.Add(-time.Hour).Format("15:04"))
+	worker.SetLimits(1000, 2000, 20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- worker.Run(ctx)
+	}()
+
+	server := api.New(log)
+	server.Register(worker)
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(func() {
+		ts.Close()
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("running worker: %v", err)
+		}
+	})
+
+	testutil.WaitFor(t, "first status check", func() bool {
+		return client.Calls("Status") > 0
+	})
+	return ts, client
+}
+
+func request(t *testing.T, method, url string, wantStatus int, result interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%s %s: status %d, want %d", method, url, resp.StatusCode, wantStatus)
+	}
+	if result == nil {
+		return
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: content type %q, want application/json", method, url, ct)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		t.Fatalf("%s %s: decoding response: %v", method, url, err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	var states []discharger.State
+	request(t, http.MethodGet, ts.URL+"/status", http.StatusOK, &states)
+	if len(states) != 1 {
+		t.Fatalf("got %d states, want 1", len(states))
+	}
+	state := states[0]
+	if state.Name != testBattery {
+		t.Errorf("name %q, want %q", state.Name, testBattery)
+	}
+	if state.IsDischarging || state.Forced {
+		t.Errorf("discharging %v, forced %v, want an idle worker", state.IsDischarging, state.Forced)
+	}
+	if state.Status == nil || state.Status.RSOC != 80 {
+		t.Errorf("status %+v, want the mock reading", state.Status)
+	}
+}
+
+func TestForceStartStop(t *testing.T) {
+	ts, client := newTestServer(t)
+	client.ExpectStartDischarge(1)
+	client.ExpectStopDischarge(1)
+
+	request(t, http.MethodPost, ts.URL+"/discharge/start?battery="+testBattery, http.StatusAccepted, nil)
+	testutil.WaitFor(t, "discharge start", func() bool {
+		return client.Power() == 2000
+	})
+	testutil.WaitFor(t, "published state", func() bool {
+		var states []discharger.State
+		request(t, http.MethodGet, ts.URL+"/status", http.StatusOK, &states)
+		return len(states) == 1 && states[0].IsDischarging && states[0].Forced
+	})
+
+	request(t, http.MethodPost, ts.URL+"/discharge/stop", http.StatusAccepted, nil)
+	testutil.WaitFor(t, "discharge stop", func() bool {
+		return client.Calls("StopDischarge") > 0
+	})
+
+	var sessions []entity.SessionSummary
+	testutil.WaitFor(t, "session summary", func() bool {
+		request(t, http.MethodGet, ts.URL+"/api/v1/sessions", http.StatusOK, &sessions)
+		return len(sessions) > 0
+	})
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	session := sessions[0]
+	if session.Battery != testBattery || session.StopReason != "forced_stop" || session.StartSoC != 80 {
+		t.Errorf("session %+v, want a forced session of %s started at 80%%", session, testBattery)
+	}
+	mocktest.AssertExpectations(t, client)
+}
+
+func TestSessionsEmpty(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	var sessions []entity.SessionSummary
+	request(t, http.MethodGet, ts.URL+"/api/v1/sessions", http.StatusOK, &sessions)
+	if sessions == nil || len(sessions) != 0 {
+		t.Errorf("sessions %v, want an empty list", sessions)
+	}
+}
+
+func TestUnknownBattery(t *testing.T) {
+	ts, client := newTestServer(t)
+	client.ExpectStartDischarge(0)
+	client.ExpectStopDischarge(0)
+
+	request(t, http.MethodPost, ts.URL+"/discharge/start?battery=garage", http.StatusNotFound, nil)
+	request(t, http.MethodPost, ts.URL+"/discharge/stop?battery=garage", http.StatusNotFound, nil)
+	mocktest.AssertExpectations(t, client)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"gok-pi/battery/load"
+	"gok-pi/internal/lib/sl"
+	"net/http"
+	"time"
+)
+
+type scheduleApplianceRequest struct {
+	Name     string    `json:"name"`
+	Duration string    `json:"duration"`
+	Deadline time.Time `json:"deadline"`
+}
+
+type scheduleApplianceResponse struct {
+	Name  string    `json:"name"`
+	Start time.Time `json:"start"`
+}
+
+// handleScheduleAppliance returns the cheapest start time for an appliance run;
+// duration is a Go duration string such as "1h30m" and deadline is RFC 3339.
+func (s *Server) handleScheduleAppliance(w http.ResponseWriter, r *http.Request) {
+	var req scheduleApplianceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || req.Name == "" {
+		http.Error(w, "name and a valid duration are required", http.StatusBadRequest)
+		return
+	}
+
+	start, err := s.scheduler.ScheduleAppliance(req.Name, duration, req.Deadline, s.tariff)
+	if errors.Is(err, load.ErrNoWindow) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		s.log.Error("scheduling appliance", sl.Err(err))
+		http.Error(w, "scheduling failed", http.StatusBadGateway)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, scheduleApplianceResponse{Name: req.Name, Start: start})
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Role grants access to API endpoints. Roles form a hierarchy, each including the rights of the ones below:
+//
+//	viewer    read-only access: status, sessions, history and analytics
+//	operator  viewer rights, plus forcing discharge start and stop and scheduling appliances
+//	admin     all endpoints, including battery reset
+type Role string
+
+const (
+	Viewer   Role = "viewer"
+	Operator Role = "operator"
+	Admin    Role = "admin"
+)
+
+var roleLevels = map[Role]int{
+	Viewer:   1,
+	Operator: 2,
+	Admin:    3,
+}
+
+// ParseRole returns the role with the given name.
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if _, ok := roleLevels[role]; !ok {
+		return "", fmt.Errorf("unknown role: %q", name)
+	}
+	return role, nil
+}
+
+// Roles are the roles granted to a user, as listed in the roles claim of the token.
+type Roles []Role
+
+// Allow tells whether any of the roles includes the rights of role; unknown roles grant nothing.
+func (r Roles) Allow(role Role) bool {
+	required, ok := roleLevels[role]
+	if !ok {
+		return false
+	}
+	for _, granted := range r {
+		if roleLevels[granted] >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole rejects requests whose token does not grant role. It must run after Middleware,
+// which puts the claims into the request context.
+func RequireRole(role Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := FromContext(r.Context())
+			if !ok {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if !claims.Roles.Allow(role) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,1204 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by oapi-codegen v2.4.1 from the OpenAPI specification. DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// DischargeInhibited defines model for DischargeInhibited.
+type DischargeInhibited struct {
+	Reason *string `json:"reason,omitempty"`
+	Time   *Time   `json:"time,omitempty"`
+}
+
+// HistoryPoint defines model for HistoryPoint.
+type HistoryPoint struct {
+	Soc *float64 `json:"soc,omitempty"`
+	T   *Time    `json:"t,omitempty"`
+}
+
+// ScheduleApplianceRequest defines model for ScheduleApplianceRequest.
+type ScheduleApplianceRequest struct {
+	Deadline *Time   `json:"deadline,omitempty"`
+	Duration *string `json:"duration,omitempty"`
+	Name     *string `json:"name,omitempty"`
+}
+
+// ScheduleApplianceResponse defines model for ScheduleApplianceResponse.
+type ScheduleApplianceResponse struct {
+	Name  *string `json:"name,omitempty"`
+	Start *Time   `json:"start,omitempty"`
+}
+
+// SessionSummary defines model for SessionSummary.
+type SessionSummary struct {
+	Battery         *string  `json:"battery,omitempty"`
+	CarbonIntensity *float64 `json:"carbon_intensity,omitempty"`
+	Co2SavedKg      *float64 `json:"co2_saved_kg,omitempty"`
+	CostSaved       *float64 `json:"cost_saved,omitempty"`
+	EndSoc          *float64 `json:"end_soc,omitempty"`
+	EnergyWh        *float64 `json:"energy_wh,omitempty"`
+	PeakPrice       *float64 `json:"peak_price,omitempty"`
+	SocLimit        *float64 `json:"soc_limit,omitempty"`
+	StartSoc        *float64 `json:"start_soc,omitempty"`
+	StartTime       *Time    `json:"start_time,omitempty"`
+	StopReason      *string  `json:"stop_reason,omitempty"`
+	StopTime        *Time    `json:"stop_time,omitempty"`
+}
+
+// State defines model for State.
+type State struct {
+	Forced        *bool               `json:"forced,omitempty"`
+	Inhibited     *DischargeInhibited `json:"inhibited"`
+	IsDischarging *bool               `json:"is_discharging,omitempty"`
+	Name          *string             `json:"name,omitempty"`
+	StartTime     *string             `json:"start_time,omitempty"`
+	Status        *SystemStatus       `json:"status"`
+	StopTime      *string             `json:"stop_time,omitempty"`
+}
+
+// SystemStatus defines model for SystemStatus.
+type SystemStatus struct {
+	ApparentOutput            *float64     `json:"Apparent_output,omitempty"`
+	BackupBuffer              *string      `json:"BackupBuffer,omitempty"`
+	BatteryCharging           *bool        `json:"BatteryCharging,omitempty"`
+	BatteryDischarging        *bool        `json:"BatteryDischarging,omitempty"`
+	ConsumptionAvg            *float64     `json:"Consumption_Avg,omitempty"`
+	ConsumptionW              *float64     `json:"Consumption_W,omitempty"`
+	Fac                       *float64     `json:"Fac,omitempty"`
+	FlowConsumptionBattery    *bool        `json:"FlowConsumptionBattery,omitempty"`
+	FlowConsumptionGrid       *bool        `json:"FlowConsumptionGrid,omitempty"`
+	FlowConsumptionProduction *bool        `json:"FlowConsumptionProduction,omitempty"`
+	FlowGridBattery           *bool        `json:"FlowGridBattery,omitempty"`
+	FlowProductionBattery     *bool        `json:"FlowProductionBattery,omitempty"`
+	FlowProductionGrid        *bool        `json:"FlowProductionGrid,omitempty"`
+	GridFeedInW               *float64     `json:"GridFeedIn_W,omitempty"`
+	IsSystemInstalled         *float64     `json:"IsSystemInstalled,omitempty"`
+	OperatingMode             *string      `json:"OperatingMode,omitempty"`
+	PacTotalW                 *float64     `json:"Pac_total_W,omitempty"`
+	ProductionW               *float64     `json:"Production_W,omitempty"`
+	RSOC                      *float64     `json:"RSOC,omitempty"`
+	RemainingCapacityWh       *float64     `json:"RemainingCapacity_Wh,omitempty"`
+	Sac1                      *float64     `json:"Sac1,omitempty"`
+	Sac2                      *interface{} `json:"Sac2,omitempty"`
+	Sac3                      *interface{} `json:"Sac3,omitempty"`
+	SystemStatus              *string      `json:"SystemStatus,omitempty"`
+	Timestamp                 *string      `json:"Timestamp,omitempty"`
+	USOC                      *float64     `json:"USOC,omitempty"`
+	Uac                       *float64     `json:"Uac,omitempty"`
+	Ubat                      *float64     `json:"Ubat,omitempty"`
+	DischargeNotAllowed       *bool        `json:"dischargeNotAllowed,omitempty"`
+	GeneratorAutostart        *bool        `json:"generator_autostart,omitempty"`
+}
+
+// Time defines model for Time.
+type Time = time.Time
+
+// WeeklyComparison defines model for WeeklyComparison.
+type WeeklyComparison struct {
+	LastWeek *WeeklyStats `json:"lastWeek,omitempty"`
+	ThisWeek *WeeklyStats `json:"thisWeek,omitempty"`
+}
+
+// WeeklyStats defines model for WeeklyStats.
+type WeeklyStats struct {
+	AvgEndSoC               *float64 `json:"avgEndSoC,omitempty"`
+	SessionsCompleted       *int     `json:"sessionsCompleted,omitempty"`
+	TotalCO2SavedKg         *float64 `json:"totalCO2SavedKg,omitempty"`
+	TotalCostSaved          *float64 `json:"totalCostSaved,omitempty"`
+	TotalEnergyDischargedWh *float64 `json:"totalEnergyDischargedWh,omitempty"`
+	WeekStart               *Time    `json:"weekStart,omitempty"`
+}
+
+// GetHistoryParams defines parameters for GetHistory.
+type GetHistoryParams struct {
+	// Start RFC 3339 start time; 24 hours before end by default
+	Start *string `form:"start,omitempty" json:"start,omitempty"`
+
+	// End RFC 3339 end time; now by default
+	End *string `form:"end,omitempty" json:"end,omitempty"`
+
+	// Step averaging interval as a Go duration; 1h by default
+	Step *string `form:"step,omitempty" json:"step,omitempty"`
+}
+
+// StartDischargeParams defines parameters for StartDischarge.
+type StartDischargeParams struct {
+	// Battery name of the battery; all batteries if omitted
+	Battery *string `form:"battery,omitempty" json:"battery,omitempty"`
+}
+
+// StopDischargeParams defines parameters for StopDischarge.
+type StopDischargeParams struct {
+	// Battery name of the battery; all batteries if omitted
+	Battery *string `form:"battery,omitempty" json:"battery,omitempty"`
+}
+
+// ScheduleApplianceJSONRequestBody defines body for ScheduleAppliance for application/json ContentType.
+type ScheduleApplianceJSONRequestBody = ScheduleApplianceRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetWeeklyComparison request
+	GetWeeklyComparison(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHistory request
+	GetHistory(ctx context.Context, name string, params *GetHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResetBattery request
+	ResetBattery(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ScheduleApplianceWithBody request with any body
+	ScheduleApplianceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ScheduleAppliance(ctx context.Context, body ScheduleApplianceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSessions request
+	GetSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartDischarge request
+	StartDischarge(ctx context.Context, params *StartDischargeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StopDischarge request
+	StopDischarge(ctx context.Context, params *StopDischargeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStatus request
+	GetStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetWeeklyComparison(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWeeklyComparisonRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHistory(ctx context.Context, name string, params *GetHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHistoryRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResetBattery(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResetBatteryRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScheduleApplianceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScheduleApplianceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ScheduleAppliance(ctx context.Context, body ScheduleApplianceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewScheduleApplianceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartDischarge(ctx context.Context, params *StartDischargeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartDischargeRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StopDischarge(ctx context.Context, params *StopDischargeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStopDischargeRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStatusRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetWeeklyComparisonRequest generates requests for GetWeeklyComparison
+func NewGetWeeklyComparisonRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/analytics/weekly-comparison")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHistoryRequest generates requests for GetHistory
+func NewGetHistoryRequest(server string, name string, params *GetHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/batteries/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Start != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "start", runtime.ParamLocationQuery, *params.Start); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.End != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "end", runtime.ParamLocationQuery, *params.End); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Step != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "step", runtime.ParamLocationQuery, *params.Step); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResetBatteryRequest generates requests for ResetBattery
+func NewResetBatteryRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/batteries/%s/reset", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewScheduleApplianceRequest calls the generic ScheduleAppliance builder with application/json body
+func NewScheduleApplianceRequest(server string, body ScheduleApplianceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewScheduleApplianceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewScheduleApplianceRequestWithBody generates requests for ScheduleAppliance with any type of body
+func NewScheduleApplianceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/schedule-appliance")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSessionsRequest generates requests for GetSessions
+func NewGetSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStartDischargeRequest generates requests for StartDischarge
+func NewStartDischargeRequest(server string, params *StartDischargeParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/discharge/start")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Battery != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "battery", runtime.ParamLocationQuery, *params.Battery); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStopDischargeRequest generates requests for StopDischarge
+func NewStopDischargeRequest(server string, params *StopDischargeParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/discharge/stop")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Battery != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "battery", runtime.ParamLocationQuery, *params.Battery); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetStatusRequest generates requests for GetStatus
+func NewGetStatusRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/status")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetWeeklyComparisonWithResponse request
+	GetWeeklyComparisonWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWeeklyComparisonResult, error)
+
+	// GetHistoryWithResponse request
+	GetHistoryWithResponse(ctx context.Context, name string, params *GetHistoryParams, reqEditors ...RequestEditorFn) (*GetHistoryResult, error)
+
+	// ResetBatteryWithResponse request
+	ResetBatteryWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ResetBatteryResult, error)
+
+	// ScheduleApplianceWithBodyWithResponse request with any body
+	ScheduleApplianceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleApplianceResult, error)
+
+	ScheduleApplianceWithResponse(ctx context.Context, body ScheduleApplianceJSONRequestBody, reqEditors ...RequestEditorFn) (*ScheduleApplianceResult, error)
+
+	// GetSessionsWithResponse request
+	GetSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionsResult, error)
+
+	// StartDischargeWithResponse request
+	StartDischargeWithResponse(ctx context.Context, params *StartDischargeParams, reqEditors ...RequestEditorFn) (*StartDischargeResult, error)
+
+	// StopDischargeWithResponse request
+	StopDischargeWithResponse(ctx context.Context, params *StopDischargeParams, reqEditors ...RequestEditorFn) (*StopDischargeResult, error)
+
+	// GetStatusWithResponse request
+	GetStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStatusResult, error)
+}
+
+type GetWeeklyComparisonResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WeeklyComparison
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWeeklyComparisonResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWeeklyComparisonResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHistoryResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]HistoryPoint
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHistoryResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHistoryResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResetBatteryResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ResetBatteryResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResetBatteryResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ScheduleApplianceResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScheduleApplianceResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ScheduleApplianceResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ScheduleApplianceResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSessionsResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]SessionSummary
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSessionsResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSessionsResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartDischargeResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r StartDischargeResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartDischargeResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StopDischargeResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r StopDischargeResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StopDischargeResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStatusResult struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]State
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStatusResult) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStatusResult) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetWeeklyComparisonWithResponse request returning *GetWeeklyComparisonResult
+func (c *ClientWithResponses) GetWeeklyComparisonWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWeeklyComparisonResult, error) {
+	rsp, err := c.GetWeeklyComparison(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWeeklyComparisonResult(rsp)
+}
+
+// GetHistoryWithResponse request returning *GetHistoryResult
+func (c *ClientWithResponses) GetHistoryWithResponse(ctx context.Context, name string, params *GetHistoryParams, reqEditors ...RequestEditorFn) (*GetHistoryResult, error) {
+	rsp, err := c.GetHistory(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHistoryResult(rsp)
+}
+
+// ResetBatteryWithResponse request returning *ResetBatteryResult
+func (c *ClientWithResponses) ResetBatteryWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*ResetBatteryResult, error) {
+	rsp, err := c.ResetBattery(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResetBatteryResult(rsp)
+}
+
+// ScheduleApplianceWithBodyWithResponse request with arbitrary body returning *ScheduleApplianceResult
+func (c *ClientWithResponses) ScheduleApplianceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleApplianceResult, error) {
+	rsp, err := c.ScheduleApplianceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScheduleApplianceResult(rsp)
+}
+
+func (c *ClientWithResponses) ScheduleApplianceWithResponse(ctx context.Context, body ScheduleApplianceJSONRequestBody, reqEditors ...RequestEditorFn) (*ScheduleApplianceResult, error) {
+	rsp, err := c.ScheduleAppliance(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScheduleApplianceResult(rsp)
+}
+
+// GetSessionsWithResponse request returning *GetSessionsResult
+func (c *ClientWithResponses) GetSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSessionsResult, error) {
+	rsp, err := c.GetSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSessionsResult(rsp)
+}
+
+// StartDischargeWithResponse request returning *StartDischargeResult
+func (c *ClientWithResponses) StartDischargeWithResponse(ctx context.Context, params *StartDischargeParams, reqEditors ...RequestEditorFn) (*StartDischargeResult, error) {
+	rsp, err := c.StartDischarge(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartDischargeResult(rsp)
+}
+
+// StopDischargeWithResponse request returning *StopDischargeResult
+func (c *ClientWithResponses) StopDischargeWithResponse(ctx context.Context, params *StopDischargeParams, reqEditors ...RequestEditorFn) (*StopDischargeResult, error) {
+	rsp, err := c.StopDischarge(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStopDischargeResult(rsp)
+}
+
+// GetStatusWithResponse request returning *GetStatusResult
+func (c *ClientWithResponses) GetStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStatusResult, error) {
+	rsp, err := c.GetStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStatusResult(rsp)
+}
+
+// ParseGetWeeklyComparisonResult parses an HTTP response from a GetWeeklyComparisonWithResponse call
+func ParseGetWeeklyComparisonResult(rsp *http.Response) (*GetWeeklyComparisonResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWeeklyComparisonResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WeeklyComparison
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHistoryResult parses an HTTP response from a GetHistoryWithResponse call
+func ParseGetHistoryResult(rsp *http.Response) (*GetHistoryResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHistoryResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []HistoryPoint
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResetBatteryResult parses an HTTP response from a ResetBatteryWithResponse call
+func ParseResetBatteryResult(rsp *http.Response) (*ResetBatteryResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResetBatteryResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseScheduleApplianceResult parses an HTTP response from a ScheduleApplianceWithResponse call
+func ParseScheduleApplianceResult(rsp *http.Response) (*ScheduleApplianceResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ScheduleApplianceResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScheduleApplianceResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSessionsResult parses an HTTP response from a GetSessionsWithResponse call
+func ParseGetSessionsResult(rsp *http.Response) (*GetSessionsResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSessionsResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []SessionSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartDischargeResult parses an HTTP response from a StartDischargeWithResponse call
+func ParseStartDischargeResult(rsp *http.Response) (*StartDischargeResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartDischargeResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseStopDischargeResult parses an HTTP response from a StopDischargeWithResponse call
+func ParseStopDischargeResult(rsp *http.Response) (*StopDischargeResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StopDischargeResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetStatusResult parses an HTTP response from a GetStatusWithResponse call
+func ParseGetStatusResult(rsp *http.Response) (*GetStatusResult, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetStatusResult{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []State
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,29 @@
+// Package client is a Go client of the battery API. The types and the endpoint methods in client.gen.go
+// are generated from the OpenAPI specification of the server by oapi-codegen; regenerate them with
+// go generate.
+package client
+
+//go:generate go run gok-pi/tools/gen-client -out client.gen.go
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const requestTimeout = 10 * time.Second
+
+// WithToken sends the token as a bearer token, as required by the admin endpoints.
+func WithToken(token string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// New returns a client of the API served at server, e.g. "http://gok-pi.local:8080", with a default
+// request timeout; pass WithHTTPClient to replace the HTTP client.
+func New(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	opts = append([]ClientOption{WithHTTPClient(&http.Client{Timeout: requestTimeout})}, opts...)
+	return NewClientWithResponses(server, opts...)
+}
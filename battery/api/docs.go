@@ -0,0 +1,51 @@
+package api
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"gok-pi/battery/api/openapi"
+	"gok-pi/internal/lib/sl"
+	"net/http"
+)
+
+const (
+	apiTitle   = "gok-pi battery API"
+	apiVersion = "1.0.0"
+	specPath   = "/api/v1/openapi.yaml"
+)
+
+// Spec returns the OpenAPI specification of all endpoints, including those of optional features.
+func Spec() (*openapi3.T, error) {
+	return spec((&Server{}).routes())
+}
+
+func spec(routes []route) (*openapi3.T, error) {
+	specs := make([]openapi.Route, 0, len(routes))
+	for _, r := range routes {
+		specs = append(specs, r.Route)
+	}
+	return openapi.Generate(apiTitle, apiVersion, specs)
+}
+
+// registerDocs serves the OpenAPI specification of the enabled routes and a SwaggerUI page showing it.
+func (s *Server) registerDocs(mux *http.ServeMux, routes []route) {
+	doc, err := spec(routes)
+	if err != nil {
+		s.log.Error("generating openapi specification", sl.Err(err))
+		return
+	}
+	document, err := openapi.YAML(doc)
+	if err != nil {
+		s.log.Error("generating openapi specification", sl.Err(err))
+		return
+	}
+	page := openapi.SwaggerUI(apiTitle, specPath)
+
+	mux.HandleFunc("GET "+specPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(document)
+	})
+	mux.HandleFunc("GET /api/v1/docs", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	})
+}
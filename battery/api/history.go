@@ -0,0 +1,112 @@
+package api
+
+import (
+	"gok-pi/battery/entity"
+	"gok-pi/battery/storage"
+	"gok-pi/internal/lib/sl"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultHistoryRange = 24 * time.Hour
+	defaultHistoryStep  = time.Hour
+	maxHistoryRange     = 31 * 24 * time.Hour
+	maxHistoryPoints    = 10000
+)
+
+type historyPoint struct {
+	Time time.Time `json:"t"`
+	SoC  float64   `json:"soc"`
+}
+
+// EnableHistory serves historical data older than the in-memory snapshots from the event store,
+// and registers the endpoints that need it.
+func (s *Server) EnableHistory(store storage.EventStore) {
+	s.store = store
+}
+
+// handleHistory returns the SoC of one battery between start and end (RFC 3339, last 24 hours by default),
+// averaged over intervals of step (a Go duration, one hour by default). The range is limited to
+// maxHistoryRange and maxHistoryPoints intervals. Recent data is served from the snapshots the worker
+// keeps in memory; older data is read from the event store.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mutex.RLock()
+	battery, ok := s.batteries[name]
+	s.mutex.RUnlock()
+	if !ok {
+		http.Error(w, "battery not found", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	end, err := parseTimeParam(query.Get("end"), time.Now())
+	if err != nil {
+		http.Error(w, "invalid end time", http.StatusBadRequest)
+		return
+	}
+	start, err := parseTimeParam(query.Get("start"), end.Add(-defaultHistoryRange))
+	if err != nil || !start.Before(end) {
+		http.Error(w, "invalid start time", http.StatusBadRequest)
+		return
+	}
+	if end.Sub(start) > maxHistoryRange {
+		http.Error(w, "time range too long", http.StatusBadRequest)
+		return
+	}
+	step := defaultHistoryStep
+	if value := query.Get("step"); value != "" {
+		step, err = time.ParseDuration(value)
+		if err != nil || step <= 0 || end.Sub(start)/step > maxHistoryPoints {
+			http.Error(w, "invalid step", http.StatusBadRequest)
+			return
+		}
+	}
+
+	snapshots, ok := recentHistory(battery.RecentSnapshots(), start, end)
+	if !ok && s.store != nil {
+		snapshots, err = s.store.QueryBatterySnapshots(r.Context(), name, start, end)
+		if err != nil {
+			s.log.Error("querying snapshots", sl.Err(err))
+			http.Error(w, "querying history failed", http.StatusBadGateway)
+			return
+		}
+	}
+
+	// snapshots are ordered by time, so readings of one interval are adjacent
+	points := make([]historyPoint, 0)
+	count := 0
+	for _, snapshot := range snapshots {
+		t := start.Add(snapshot.Time.Sub(start).Truncate(step)).UTC()
+		if count > 0 && points[len(points)-1].Time.Equal(t) {
+			last := &points[len(points)-1]
+			last.SoC = (last.SoC*float64(count) + snapshot.SoC) / float64(count+1)
+			count++
+			continue
+		}
+		points = append(points, historyPoint{Time: t, SoC: snapshot.SoC})
+		count = 1
+	}
+	s.writeJSON(w, http.StatusOK, points)
+}
+
+// recentHistory returns the in-memory snapshots within [start, end); ok is false if they do not
+// reach back to start, so older data has to be read from the event store.
+func recentHistory(recent []entity.BatterySnapshot, start, end time.Time) ([]entity.BatterySnapshot, bool) {
+	ok := len(recent) > 0 && !recent[0].Time.After(start)
+	var snapshots []entity.BatterySnapshot
+	for _, snapshot := range recent {
+		if !snapshot.Time.Before(start) && snapshot.Time.Before(end) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, ok
+}
+
+func parseTimeParam(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
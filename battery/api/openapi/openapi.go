@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"reflect"
+	"regexp"
+	"unicode"
+)
+
+const Version = "3.0.3"
+
+var pathParam = regexp.MustCompile(`\{([^}.]+)(\.\.\.)?}`)
+
+// Parameter is an OpenAPI parameter object.
+type Parameter = openapi3.Parameter
+
+// Route describes one API endpoint; the spec is generated from the same route list that the server registers.
+type Route struct {
+	Method string
+	Path   string
+	// OperationID names the operation, e.g. as the method name of a generated client.
+	OperationID string
+	Summary     string
+	// Query lists the query parameters; path parameters are taken from the path.
+	Query []*Parameter
+	// Request and Response are sample values of the body types, nil for no body.
+	Request  interface{}
+	Response interface{}
+	// Status is the status code of a successful response, 200 by default.
+	Status   int
+	Security bool
+}
+
+// QueryParam describes an optional string query parameter.
+func QueryParam(name, description string) *Parameter {
+	return openapi3.NewQueryParameter(name).
+		WithDescription(description).
+		WithSchema(openapi3.NewStringSchema())
+}
+
+// Generate builds and validates the specification of the given routes. Schemas of the body types
+// are derived from their JSON encoding; named struct types become component schemas.
+// Routes with Security set require a Bearer token.
+func Generate(title, version string, routes []Route) (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: Version,
+		Info:    &openapi3.Info{Title: title, Version: version},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{
+				// the generator refers to time.Time fields as a component, but does not define it
+				"Time": openapi3.NewDateTimeSchema().NewRef(),
+			},
+		},
+	}
+	schemas := openapi3gen.NewGenerator(
+		openapi3gen.UseAllExportedFields(),
+		openapi3gen.CreateTypeNameGenerator(typeName),
+		openapi3gen.CreateComponentSchemas(openapi3gen.ExportComponentSchemasOptions{
+			ExportComponentSchemas: true,
+			ExportTopLevelSchema:   true,
+		}),
+	)
+	for _, route := range routes {
+		op, err := operation(schemas, doc.Components.Schemas, route)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+		doc.AddOperation(route.Path, route.Method, op)
+		if route.Security {
+			doc.Components.SecuritySchemes = openapi3.SecuritySchemes{
+				"bearerAuth": &openapi3.SecuritySchemeRef{Value: (&openapi3.SecurityScheme{}).WithType("http").WithScheme("bearer")},
+			}
+		}
+	}
+	if err := openapi3.NewLoader().ResolveRefsIn(doc, nil); err != nil {
+		return nil, fmt.Errorf("resolving schemas: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("validating specification: %w", err)
+	}
+	return doc, nil
+}
+
+// YAML returns the document encoded as YAML, indented by two spaces.
+func YAML(doc *openapi3.T) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func operation(g *openapi3gen.Generator, schemas openapi3.Schemas, route Route) (*openapi3.Operation, error) {
+	op := &openapi3.Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Responses:   openapi3.NewResponsesWithCapacity(1),
+	}
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		param := openapi3.NewPathParameter(match[1]).WithSchema(openapi3.NewStringSchema())
+		op.AddParameter(param)
+	}
+	for _, param := range route.Query {
+		op.AddParameter(param)
+	}
+	if route.Request != nil {
+		schema, err := g.NewSchemaRefForValue(route.Request, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("request schema: %w", err)
+		}
+		op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+			WithRequired(true).
+			WithJSONSchemaRef(schema)}
+	}
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+	if route.Response != nil {
+		schema, err := g.NewSchemaRefForValue(route.Response, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("response schema: %w", err)
+		}
+		response.WithJSONSchemaRef(schema)
+	}
+	op.AddResponse(status, response)
+	if route.Security {
+		op.Security = openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate("bearerAuth"))
+	}
+	return op, nil
+}
+
+// typeName names the component schema of a struct type, capitalising unexported names,
+// e.g. historyPoint becomes HistoryPoint.
+func typeName(t reflect.Type) string {
+	runes := []rune(t.Name())
+	if len(runes) == 0 {
+		return ""
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"bytes"
+	"html/template"
+)
+
+const swaggerUIVersion = "5.17.14"
+
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "{{.SpecURL}}", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`))
+
+// SwaggerUI returns an HTML page rendering the specification at specURL with SwaggerUI, loaded from a CDN.
+func SwaggerUI(title, specURL string) []byte {
+	var buf bytes.Buffer
+	_ = swaggerUITemplate.Execute(&buf, struct {
+		Title   string
+		Version string
+		SpecURL string
+	}{title, swaggerUIVersion, specURL})
+	return buf.Bytes()
+}
@@ -0,0 +1,95 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gok-pi/battery/scheduler/ical"
+	"gok-pi/internal/lib/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var httpClient = &http.Client{}
+
+// ICSCalendar is a public holiday calendar published as an iCalendar (.ics) URL. Every event of the
+// calendar is a holiday; the calendar is reloaded periodically, and kept when a reload fails.
+type ICSCalendar struct {
+	url      string
+	holidays []ical.Event
+	mutex    sync.RWMutex
+	log      *slog.Logger
+}
+
+func New(url string, log *slog.Logger) *ICSCalendar {
+	log.With(slog.String("url", url)).Info("creating holiday calendar")
+	return &ICSCalendar{
+		url: url,
+		log: log.With(sl.Module("battery.calendar")),
+	}
+}
+
+// IsHoliday tells whether t falls on a holiday of the last loaded calendar.
+func (c *ICSCalendar) IsHoliday(t time.Time) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, holiday := range c.holidays {
+		if holiday.Window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run loads the calendar right away and then every interval, until the context is cancelled.
+func (c *ICSCalendar) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Load(ctx); err != nil {
+			c.log.With(sl.Err(err)).Warn("loading holiday calendar; keeping the last known holidays")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Load downloads and parses the calendar, replacing the known holidays.
+func (c *ICSCalendar) Load(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timeout")
+		}
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	holidays, err := ical.ParseEvents(resp.Body)
+	if err != nil {
+		return fmt.Errorf("parsing calendar: %w", err)
+	}
+	c.mutex.Lock()
+	c.holidays = holidays
+	c.mutex.Unlock()
+	c.log.With(slog.Int("holidays", len(holidays))).Debug("holiday calendar loaded")
+	return nil
+}
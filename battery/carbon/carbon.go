@@ -0,0 +1,5 @@
+package carbon
+
+import "errors"
+
+var ErrNoIntensity = errors.New("no carbon intensity available")
@@ -0,0 +1,18 @@
+package static
+
+import "time"
+
+// StaticIntensity implements discharger.CarbonSource with a fixed grid carbon intensity,
+// e.g. the yearly average published for the country.
+type StaticIntensity struct {
+	intensity float64
+}
+
+// New creates a source returning intensity, in gCO2/kWh, at all times.
+func New(intensity float64) *StaticIntensity {
+	return &StaticIntensity{intensity: intensity}
+}
+
+func (s *StaticIntensity) IntensityAt(_ time.Time) (float64, error) {
+	return s.intensity, nil
+}
@@ -0,0 +1,149 @@
+package ukgrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gok-pi/battery/carbon"
+	"gok-pi/internal/lib/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cacheTtl = 30 * time.Minute
+	// timeFormat is the ISO 8601 format without seconds used by the API, always in UTC
+	timeFormat = "2006-01-02T15:04Z"
+)
+
+var (
+	apiUrl     = "https://api.carbonintensity.org.uk"
+	httpClient = &http.Client{}
+)
+
+type period struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Intensity struct {
+		Forecast *float64 `json:"forecast"`
+		Actual   *float64 `json:"actual"`
+	} `json:"intensity"`
+}
+
+type intensityResponse struct {
+	Data []period `json:"data"`
+}
+
+// interval is a half-hour period with its actual intensity, or the forecast until it is measured.
+type interval struct {
+	from      time.Time
+	to        time.Time
+	intensity float64
+}
+
+// Client implements discharger.CarbonSource with the half-hourly national intensity
+// of the GB grid published by National Grid ESO.
+type Client struct {
+	intervals []interval
+	fetchedAt time.Time
+	mutex     sync.Mutex
+	log       *slog.Logger
+}
+
+func New(log *slog.Logger) *Client {
+	return &Client{
+		log: log.With(sl.Module("carbon.ukgrid")),
+	}
+}
+
+// IntensityAt returns the carbon intensity in gCO2/kWh of the half-hour period containing t.
+func (c *Client) IntensityAt(t time.Time) (float64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.intervals == nil || time.Since(c.fetchedAt) > cacheTtl {
+		intervals, err := c.fetchIntensity(time.Now())
+		if err != nil {
+			return 0, err
+		}
+		c.intervals = intervals
+		c.fetchedAt = time.Now()
+	}
+
+	for _, i := range c.intervals {
+		if !t.Before(i.from) && t.Before(i.to) {
+			return i.intensity, nil
+		}
+	}
+	return 0, carbon.ErrNoIntensity
+}
+
+// fetchIntensity loads the periods from the start of the previous day to 24 hours from now.
+func (c *Client) fetchIntensity(now time.Time) ([]interval, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	from := now.UTC().Add(-24 * time.Hour).Truncate(30 * time.Minute)
+	path := fmt.Sprintf("%s/intensity/%s/fw48h", apiUrl, from.Format(timeFormat))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timeout")
+		}
+		c.log.Error("fetching carbon intensity", sl.Err(err))
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+
+	var response intensityResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding carbon intensity: %w", err)
+	}
+	intervals := make([]interval, 0, len(response.Data))
+	for _, p := range response.Data {
+		i, ok := p.interval()
+		if !ok {
+			continue
+		}
+		intervals = append(intervals, i)
+	}
+	c.log.With(slog.Int("periods", len(intervals))).Debug("fetched carbon intensity")
+	return intervals, nil
+}
+
+func (p period) interval() (interval, bool) {
+	from, err := time.Parse(timeFormat, strings.TrimSpace(p.From))
+	if err != nil {
+		return interval{}, false
+	}
+	to, err := time.Parse(timeFormat, strings.TrimSpace(p.To))
+	if err != nil {
+		return interval{}, false
+	}
+	intensity := p.Intensity.Actual
+	if intensity == nil {
+		intensity = p.Intensity.Forecast
+	}
+	if intensity == nil {
+		return interval{}, false
+	}
+	return interval{from: from, to: to, intensity: *intensity}, true
+}
@@ -0,0 +1,55 @@
+package ukgrid
+
+import (
+	"gok-pi/battery/carbon"
+	"gok-pi/internal/lib/testutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const response = `{"data":[
+{"from":"2024-05-01T12:00Z","to":"2024-05-01T12:30Z","intensity":{"forecast":120,"actual":131,"index":"low"}},
+{"from":"2024-05-01T12:30Z","to":"2024-05-01T13:00Z","intensity":{"forecast":140,"actual":null,"index":"moderate"}},
+{"from":"2024-05-01T13:00Z","to":"2024-05-01T13:30Z","intensity":{"forecast":null,"actual":null,"index":"moderate"}}
+]}`
+
+func TestIntensityAt(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !strings.HasPrefix(r.URL.Path, "/intensity/") || !strings.HasSuffix(r.URL.Path, "/fw48h") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	apiUrl = server.URL
+
+	client := New(testutil.NewTestLogger(t))
+	tests := []struct {
+		time time.Time
+		want float64
+	}{
+		// the measured intensity is preferred over the forecast
+		{time.Date(2024, time.May, 1, 12, 10, 0, 0, time.UTC), 131},
+		{time.Date(2024, time.May, 1, 13, 30, 0, 0, time.FixedZone("BST", 3600)), 140},
+	}
+	for _, tt := range tests {
+		got, err := client.IntensityAt(tt.time)
+		if err != nil {
+			t.Fatalf("intensity at %s: %v", tt.time, err)
+		}
+		if got != tt.want {
+			t.Errorf("intensity at %s: %v, want %v", tt.time, got, tt.want)
+		}
+	}
+
+	_, err := client.IntensityAt(time.Date(2024, time.May, 1, 13, 10, 0, 0, time.UTC))
+	testutil.AssertErrorIs(t, err, carbon.ErrNoIntensity)
+	if requests != 1 {
+		t.Errorf("%d requests, want the periods to be cached", requests)
+	}
+}
@@ -0,0 +1,225 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"gok-pi/battery/entity"
+	"sort"
+	"sync"
+)
+
+var ErrNoStatus = errors.New("no status set")
+
+// MockClient is an in-memory battery client for simulations and tests. Status readings are replayed
+// from a sequence and every command is counted; it is safe for concurrent use.
+type MockClient struct {
+	sequence []entity.SystemStatus
+	next     int
+	meters   entity.EnergyMeterSnapshot
+	daily    entity.DailyBatteryStats
+	calls    map[string]int
+	expect   map[string]int
+	inject   map[string]*injection
+	power    int
+	err      error
+	mutex    sync.Mutex
+}
+
+// injection is an error returned once, after a number of successful calls.
+type injection struct {
+	err       error
+	remaining int
+}
+
+func New() *MockClient {
+	return &MockClient{
+		calls:  make(map[string]int),
+		expect: make(map[string]int),
+		inject: make(map[string]*injection),
+	}
+}
+
+// SetStatusSequence makes each Status call return the next reading of seq, cycling back to the start
+// when the sequence is exhausted.
+func (c *MockClient) SetStatusSequence(seq []entity.SystemStatus) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sequence = append([]entity.SystemStatus(nil), seq...)
+	c.next = 0
+}
+
+// InjectError makes the named method, e.g. "StartDischarge", fail once with err after afterNCalls
+// more successful calls. A later injection for the same method replaces a pending one.
+func (c *MockClient) InjectError(method string, err error, afterNCalls int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.inject[method] = &injection{err: err, remaining: max(afterNCalls, 0)}
+}
+
+// SetError makes every call fail with err until it is reset with nil.
+func (c *MockClient) SetError(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.err = err
+}
+
+func (c *MockClient) SetEnergyMeters(meters entity.EnergyMeterSnapshot) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.meters = meters
+}
+
+func (c *MockClient) SetDailyStats(stats entity.DailyBatteryStats) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.daily = stats
+}
+
+// Calls returns how many times the named method was called, e.g. "StopDischarge".
+func (c *MockClient) Calls(method string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.calls[method]
+}
+
+// Expect sets how many times the named method is expected to be called, checked by CheckExpectations.
+func (c *MockClient) Expect(method string, times int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.expect[method] = times
+}
+
+func (c *MockClient) ExpectStartDischarge(times int) {
+	c.Expect("StartDischarge", times)
+}
+
+func (c *MockClient) ExpectStopDischarge(times int) {
+	c.Expect("StopDischarge", times)
+}
+
+func (c *MockClient) ExpectSetOperatingMode(times int) {
+	c.Expect("SetOperatingMode", times)
+}
+
+// CheckExpectations returns an error listing every method whose call count differs from its expectation,
+// or nil if all expectations are met. Tests can fail with it through mocktest.AssertExpectations.
+func (c *MockClient) CheckExpectations() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	methods := make([]string, 0, len(c.expect))
+	for method := range c.expect {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	var errs []error
+	for _, method := range methods {
+		if calls := c.calls[method]; calls != c.expect[method] {
+			errs = append(errs, fmt.Errorf("mock client: %s called %d times, expected %d", method, calls, c.expect[method]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Power returns the power of the last StartDischarge call, or zero after StopDischarge.
+func (c *MockClient) Power() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.power
+}
+
+func (c *MockClient) Status() (*entity.SystemStatus, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.count("Status"); err != nil {
+		return nil, err
+	}
+	if len(c.sequence) == 0 {
+		return nil, ErrNoStatus
+	}
+	status := c.sequence[c.next]
+	c.next = (c.next + 1) % len(c.sequence)
+	return &status, nil
+}
+
+func (c *MockClient) EnergyMeters() (*entity.EnergyMeterSnapshot, error) {
+	if err := c.call("EnergyMeters"); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	meters := c.meters
+	return &meters, nil
+}
+
+func (c *MockClient) DailyStats() (*entity.DailyBatteryStats, error) {
+	if err := c.call("DailyStats"); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stats := c.daily
+	return &stats, nil
+}
+
+func (c *MockClient) StartDischarge(power int) error {
+	if err := c.call("StartDischarge"); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.power = power
+	return nil
+}
+
+func (c *MockClient) StopDischarge() error {
+	if err := c.call("StopDischarge"); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.power = 0
+	return nil
+}
+
+func (c *MockClient) StartCharge(_ int) error {
+	return c.call("StartCharge")
+}
+
+func (c *MockClient) StopCharge() error {
+	return c.call("StopCharge")
+}
+
+func (c *MockClient) SetOperatingMode(_ entity.OperatingMode) error {
+	return c.call("SetOperatingMode")
+}
+
+func (c *MockClient) Reset() error {
+	return c.call("Reset")
+}
+
+func (c *MockClient) Ping() error {
+	return c.call("Ping")
+}
+
+// call counts a method call and returns the error to fail it with, if any.
+func (c *MockClient) call(method string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.count(method)
+}
+
+// count must be called with the mutex held.
+func (c *MockClient) count(method string) error {
+	c.calls[method]++
+	if c.err != nil {
+		return c.err
+	}
+	if inj, ok := c.inject[method]; ok {
+		if inj.remaining == 0 {
+			delete(c.inject, method)
+			return inj.err
+		}
+		inj.remaining--
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Package mocktest holds the test helpers of the mock client. They are kept apart from package mock,
+// so the simulator and other non-test users of the mock do not import testing.
+package mocktest
+
+import (
+	"gok-pi/battery/client/mock"
+	"testing"
+)
+
+// AssertExpectations fails the test if a method of the client was not called as often as expected,
+// see MockClient.Expect.
+func AssertExpectations(t testing.TB, client *mock.MockClient) {
+	t.Helper()
+	if err := client.CheckExpectations(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,70 @@
+package pool
+
+import (
+	"errors"
+	"gok-pi/battery/discharger"
+	"sync"
+	"time"
+)
+
+var ErrPoolExhausted = errors.New("all connections are in use")
+
+// Pool shares a limited number of client connections between batteries behind one gateway.
+// Connections are created on demand up to the maximum and reused after they are returned.
+type Pool struct {
+	factory func() (discharger.Client, error)
+	timeout time.Duration
+	slots   chan struct{}
+	idle    []discharger.Client
+	mutex   sync.Mutex
+}
+
+// New creates a pool of at most size connections made by factory; Get waits up to timeout for a free one.
+func New(size int, timeout time.Duration, factory func() (discharger.Client, error)) *Pool {
+	return &Pool{
+		factory: factory,
+		timeout: timeout,
+		slots:   make(chan struct{}, max(size, 1)),
+	}
+}
+
+// Get borrows a connection. The returned function gives it back and must be called exactly once.
+// If all connections stay borrowed for the pool timeout, Get returns ErrPoolExhausted.
+func (p *Pool) Get() (discharger.Client, func(), error) {
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+	case <-timer.C:
+		return nil, nil, ErrPoolExhausted
+	}
+
+	client, err := p.take()
+	if err != nil {
+		<-p.slots
+		return nil, nil, err
+	}
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			p.mutex.Lock()
+			p.idle = append(p.idle, client)
+			p.mutex.Unlock()
+			<-p.slots
+		})
+	}
+	return client, release, nil
+}
+
+// take returns an idle connection or creates a new one.
+func (p *Pool) take() (discharger.Client, error) {
+	p.mutex.Lock()
+	if n := len(p.idle); n > 0 {
+		client := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mutex.Unlock()
+		return client, nil
+	}
+	p.mutex.Unlock()
+	return p.factory()
+}
@@ -0,0 +1,198 @@
+package reconnect
+
+import (
+	"context"
+	"errors"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+)
+
+var ErrReconnecting = errors.New("client is reconnecting")
+
+// ReconnectClient wraps a Client that loses its connection, e.g. after a router reboot or a BMS
+// firmware update. When a call fails with a connection error, the client is built again by its constructor in the background,
+// with exponential backoff and at most maxAttempts tries. Until the connection is back, Status returns
+// the last known reading marked as stale and other calls return ErrReconnecting.
+type ReconnectClient struct {
+	connect      func() (discharger.Client, error)
+	maxAttempts  int
+	client       discharger.Client
+	last         *entity.SystemStatus
+	reconnecting bool
+	mutex        sync.Mutex
+	log          *slog.Logger
+}
+
+// New connects a client by calling connect and returns it wrapped, or the error of the first connection.
+func New(connect func() (discharger.Client, error), maxAttempts int, log *slog.Logger) (*ReconnectClient, error) {
+	client, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	return &ReconnectClient{
+		connect:     connect,
+		maxAttempts: max(maxAttempts, 1),
+		client:      client,
+		log:         log.With(sl.Module("client.reconnect")),
+	}, nil
+}
+
+func (c *ReconnectClient) Status() (*entity.SystemStatus, error) {
+	client, err := c.current()
+	if err == nil {
+		var status *entity.SystemStatus
+		status, err = client.Status()
+		if err == nil {
+			c.mutex.Lock()
+			last := status.Clone()
+			c.last = &last
+			c.mutex.Unlock()
+			return status, nil
+		}
+		c.failed(err)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.last == nil {
+		return nil, err
+	}
+	stale := c.last.Clone()
+	stale.Stale = true
+	return &stale, nil
+}
+
+func (c *ReconnectClient) EnergyMeters() (*entity.EnergyMeterSnapshot, error) {
+	client, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	meters, err := client.EnergyMeters()
+	c.failed(err)
+	return meters, err
+}
+
+func (c *ReconnectClient) DailyStats() (*entity.DailyBatteryStats, error) {
+	client, err := c.current()
+	if err != nil {
+		return nil, err
+	}
+	stats, err := client.DailyStats()
+	c.failed(err)
+	return stats, err
+}
+
+func (c *ReconnectClient) StartDischarge(power int) error {
+	return c.call(func(client discharger.Client) error {
+		return client.StartDischarge(power)
+	})
+}
+
+func (c *ReconnectClient) StopDischarge() error {
+	return c.call(discharger.Client.StopDischarge)
+}
+
+func (c *ReconnectClient) StartCharge(power int) error {
+	return c.call(func(client discharger.Client) error {
+		return client.StartCharge(power)
+	})
+}
+
+func (c *ReconnectClient) StopCharge() error {
+	return c.call(discharger.Client.StopCharge)
+}
+
+func (c *ReconnectClient) SetOperatingMode(mode entity.OperatingMode) error {
+	return c.call(func(client discharger.Client) error {
+		return client.SetOperatingMode(mode)
+	})
+}
+
+func (c *ReconnectClient) Reset() error {
+	return c.call(discharger.Client.Reset)
+}
+
+func (c *ReconnectClient) Ping() error {
+	return c.call(discharger.Client.Ping)
+}
+
+func (c *ReconnectClient) call(fn func(client discharger.Client) error) error {
+	client, err := c.current()
+	if err != nil {
+		return err
+	}
+	err = fn(client)
+	c.failed(err)
+	return err
+}
+
+// current returns the connected client, or ErrReconnecting while a reconnection is in progress.
+func (c *ReconnectClient) current() (discharger.Client, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.reconnecting {
+		return nil, ErrReconnecting
+	}
+	return c.client, nil
+}
+
+// failed starts a reconnection after a connection error, unless one is already running.
+// Other errors, e.g. an unsupported operation or an HTTP error status, are returned as they are.
+func (c *ReconnectClient) failed(err error) {
+	if !isConnectionError(err) {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.reconnecting {
+		return
+	}
+	c.reconnecting = true
+	c.log.With(sl.Err(err)).Warn("call failed; reconnecting")
+	go c.reconnect()
+}
+
+func (c *ReconnectClient) reconnect() {
+	backoff := initialBackoff
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		time.Sleep(backoff)
+		client, err := c.connect()
+		if err == nil {
+			c.mutex.Lock()
+			c.client = client
+			c.reconnecting = false
+			c.mutex.Unlock()
+			c.log.Info("reconnected", slog.Int("attempt", attempt))
+			return
+		}
+		c.log.With(sl.Err(err)).Warn("reconnect failed", slog.Int("attempt", attempt))
+		backoff = min(backoff*2, maxBackoff)
+	}
+	c.log.Error("giving up reconnecting", slog.Int("attempts", c.maxAttempts))
+	// keep the old client; the next failed call starts over
+	c.mutex.Lock()
+	c.reconnecting = false
+	c.mutex.Unlock()
+}
+
+// isConnectionError tells whether err comes from the transport rather than from the battery API.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
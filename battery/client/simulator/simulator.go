@@ -0,0 +1,141 @@
+package simulator
+
+import (
+	"errors"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var ErrSimulated = errors.New("simulated client error")
+
+// LatencySimulatorClient wraps a Client and delays every call by a random duration
+// drawn from a normal distribution, to mimic the response times of a real BMS.
+// It can also fail calls at a configurable rate to exercise error handling.
+type LatencySimulatorClient struct {
+	client    discharger.Client
+	mean      time.Duration
+	stdDev    time.Duration
+	errorRate float64
+	rnd       *rand.Rand
+	mutex     sync.Mutex
+	log       *slog.Logger
+}
+
+func New(client discharger.Client, mean, stdDev time.Duration, seed int64, log *slog.Logger) *LatencySimulatorClient {
+	return &LatencySimulatorClient{
+		client: client,
+		mean:   mean,
+		stdDev: stdDev,
+		rnd:    rand.New(rand.NewSource(seed)),
+		log:    log.With(sl.Module("client.simulator")),
+	}
+}
+
+// SetErrorRate sets the probability (0.0–1.0) of a call returning ErrSimulated
+// instead of reaching the wrapped client.
+func (c *LatencySimulatorClient) SetErrorRate(rate float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.errorRate = min(max(rate, 0), 1)
+}
+
+func (c *LatencySimulatorClient) Status() (*entity.SystemStatus, error) {
+	if err := c.simulate("status"); err != nil {
+		return nil, err
+	}
+	return c.client.Status()
+}
+
+func (c *LatencySimulatorClient) EnergyMeters() (*entity.EnergyMeterSnapshot, error) {
+	if err := c.simulate("energy meters"); err != nil {
+		return nil, err
+	}
+	return c.client.EnergyMeters()
+}
+
+func (c *LatencySimulatorClient) DailyStats() (*entity.DailyBatteryStats, error) {
+	if err := c.simulate("daily stats"); err != nil {
+		return nil, err
+	}
+	return c.client.DailyStats()
+}
+
+func (c *LatencySimulatorClient) StartDischarge(power int) error {
+	if err := c.simulate("start discharge"); err != nil {
+		return err
+	}
+	return c.client.StartDischarge(power)
+}
+
+func (c *LatencySimulatorClient) StopDischarge() error {
+	if err := c.simulate("stop discharge"); err != nil {
+		return err
+	}
+	return c.client.StopDischarge()
+}
+
+func (c *LatencySimulatorClient) StartCharge(power int) error {
+	if err := c.simulate("start charge"); err != nil {
+		return err
+	}
+	return c.client.StartCharge(power)
+}
+
+func (c *LatencySimulatorClient) StopCharge() error {
+	if err := c.simulate("stop charge"); err != nil {
+		return err
+	}
+	return c.client.StopCharge()
+}
+
+func (c *LatencySimulatorClient) SetOperatingMode(mode entity.OperatingMode) error {
+	if err := c.simulate("set operating mode"); err != nil {
+		return err
+	}
+	return c.client.SetOperatingMode(mode)
+}
+
+func (c *LatencySimulatorClient) Reset() error {
+	if err := c.simulate("reset"); err != nil {
+		return err
+	}
+	return c.client.Reset()
+}
+
+func (c *LatencySimulatorClient) Ping() error {
+	if err := c.simulate("ping"); err != nil {
+		return err
+	}
+	return c.client.Ping()
+}
+
+// simulate sleeps for a random latency and returns ErrSimulated if the call was chosen to fail.
+func (c *LatencySimulatorClient) simulate(call string) error {
+	latency, fail := c.next()
+	time.Sleep(latency)
+	if fail {
+		c.log.With(
+			slog.String("call", call),
+			slog.Duration("latency", latency),
+		).Debug("injecting error")
+		return ErrSimulated
+	}
+	return nil
+}
+
+// next draws the latency and failure decision for one call; negative latencies are clamped to zero.
+func (c *LatencySimulatorClient) next() (time.Duration, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	latency := c.mean + time.Duration(c.rnd.NormFloat64()*float64(c.stdDev))
+	if latency < 0 {
+		latency = 0
+	}
+	fail := c.errorRate > 0 && c.rnd.Float64() < c.errorRate
+	return latency, fail
+}
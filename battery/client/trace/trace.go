@@ -0,0 +1,166 @@
+//go:build otel
+
+// Package trace records battery client calls as OpenTelemetry spans. Like the metrics/otel package,
+// it is only built with the "otel" build tag, so default builds do not compile OpenTelemetry.
+package trace
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+)
+
+// TraceClient wraps a Client and records every call as an OpenTelemetry span. The ...Context methods
+// start the span as a child of the span carried by ctx; the methods of the Client interface start
+// a root span.
+type TraceClient struct {
+	name   string
+	client discharger.Client
+	tracer trace.Tracer
+}
+
+func New(name string, client discharger.Client, tracer trace.Tracer) *TraceClient {
+	return &TraceClient{
+		name:   name,
+		client: client,
+		tracer: tracer,
+	}
+}
+
+func (c *TraceClient) Status() (*entity.SystemStatus, error) {
+	return c.StatusContext(context.Background())
+}
+
+func (c *TraceClient) StatusContext(ctx context.Context) (*entity.SystemStatus, error) {
+	span := c.start(ctx, "client.Status")
+	status, err := c.client.Status()
+	if status != nil {
+		span.SetAttributes(
+			attribute.Float64("battery.rsoc", status.RSOC),
+			attribute.String("battery.operating_mode", status.OperatingMode),
+		)
+	}
+	c.end(span, err)
+	return status, err
+}
+
+func (c *TraceClient) EnergyMeters() (*entity.EnergyMeterSnapshot, error) {
+	return c.EnergyMetersContext(context.Background())
+}
+
+func (c *TraceClient) EnergyMetersContext(ctx context.Context) (*entity.EnergyMeterSnapshot, error) {
+	span := c.start(ctx, "client.EnergyMeters")
+	meters, err := c.client.EnergyMeters()
+	c.end(span, err)
+	return meters, err
+}
+
+func (c *TraceClient) DailyStats() (*entity.DailyBatteryStats, error) {
+	return c.DailyStatsContext(context.Background())
+}
+
+func (c *TraceClient) DailyStatsContext(ctx context.Context) (*entity.DailyBatteryStats, error) {
+	span := c.start(ctx, "client.DailyStats")
+	stats, err := c.client.DailyStats()
+	c.end(span, err)
+	return stats, err
+}
+
+func (c *TraceClient) StartDischarge(power int) error {
+	return c.StartDischargeContext(context.Background(), power)
+}
+
+func (c *TraceClient) StartDischargeContext(ctx context.Context, power int) error {
+	span := c.start(ctx, "client.StartDischarge", attribute.Int("battery.power", power))
+	err := c.client.StartDischarge(power)
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) StopDischarge() error {
+	return c.StopDischargeContext(context.Background())
+}
+
+func (c *TraceClient) StopDischargeContext(ctx context.Context) error {
+	span := c.start(ctx, "client.StopDischarge")
+	err := c.client.StopDischarge()
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) StartCharge(power int) error {
+	return c.StartChargeContext(context.Background(), power)
+}
+
+func (c *TraceClient) StartChargeContext(ctx context.Context, power int) error {
+	span := c.start(ctx, "client.StartCharge", attribute.Int("battery.power", power))
+	err := c.client.StartCharge(power)
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) StopCharge() error {
+	return c.StopChargeContext(context.Background())
+}
+
+func (c *TraceClient) StopChargeContext(ctx context.Context) error {
+	span := c.start(ctx, "client.StopCharge")
+	err := c.client.StopCharge()
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) SetOperatingMode(mode entity.OperatingMode) error {
+	return c.SetOperatingModeContext(context.Background(), mode)
+}
+
+func (c *TraceClient) SetOperatingModeContext(ctx context.Context, mode entity.OperatingMode) error {
+	span := c.start(ctx, "client.SetOperatingMode", attribute.String("battery.operating_mode", mode.String()))
+	err := c.client.SetOperatingMode(mode)
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) Reset() error {
+	return c.ResetContext(context.Background())
+}
+
+func (c *TraceClient) ResetContext(ctx context.Context) error {
+	span := c.start(ctx, "client.Reset")
+	err := c.client.Reset()
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) Ping() error {
+	return c.PingContext(context.Background())
+}
+
+func (c *TraceClient) PingContext(ctx context.Context) error {
+	span := c.start(ctx, "client.Ping")
+	err := c.client.Ping()
+	c.end(span, err)
+	return err
+}
+
+func (c *TraceClient) start(ctx context.Context, spanName string, attrs ...attribute.KeyValue) trace.Span {
+	_, span := c.tracer.Start(ctx, spanName, trace.WithAttributes(
+		append(attrs, attribute.String("battery.name", c.name))...,
+	))
+	return span
+}
+
+// end records the call result on the span and ends it; errors are added as span events.
+func (c *TraceClient) end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attribute.String("result", "error"))
+	} else {
+		span.SetAttributes(attribute.String("result", "ok"))
+	}
+	span.End()
+}
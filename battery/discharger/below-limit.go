@@ -0,0 +1,156 @@
+package discharger
+
+import (
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/metrics/observers"
+	"log/slog"
+	"time"
+)
+
+// BelowLimitBehaviour is what the worker does when the SoC is already at or below the limit
+// when a scheduled window starts.
+type BelowLimitBehaviour int
+
+const (
+	// BelowLimitSkip skips the window; discharge starts later in the window only if the battery recharges
+	// above the limit.
+	BelowLimitSkip BelowLimitBehaviour = iota
+	// BelowLimitDischarge discharges for the full window regardless of the SoC limit, leaving the cut-off
+	// to the inverter; useful with a limit of 0%.
+	BelowLimitDischarge
+	// BelowLimitCharge charges the battery from the grid until it reaches the charge target or the window
+	// ends; the battery is not discharged in that window.
+	BelowLimitCharge
+)
+
+// startedBelowLimit tells whether the current window started below the SoC limit. The first check within
+// each window decides; that is logged once.
+func (d *Discharge) startedBelowLimit() bool {
+	if d.status == nil {
+		return false
+	}
+	window, ok := d.dischargeWindow(time.Now())
+	if !ok {
+		return false
+	}
+	if !d.belowLimitAt.Equal(window.Start) {
+		d.belowLimitAt = window.Start
+		d.belowLimitStart = !d.isDischarging && !d.isReadyToDischarge()
+		if d.belowLimitStart {
+			log := d.log.With(
+				slog.Float64("SoC", d.status.RSOC),
+				slog.Float64("limit", d.socLimit),
+			)
+			switch d.belowLimit {
+			case BelowLimitDischarge:
+				d.logEvent(log, EventSchedule, "SoC below the limit, discharging for the full window")
+			case BelowLimitCharge:
+				d.logEvent(log.With(slog.Float64("target", d.chargeTargetSoC())), EventSchedule,
+					"SoC below the limit, charging to the target")
+			default:
+				d.logEvent(log, EventSchedule, "SoC below the limit, discharge skipped")
+			}
+		}
+	}
+	return d.belowLimitStart
+}
+
+// dischargeBelowLimit tells whether the worker discharges in a window that started below the SoC limit.
+func (d *Discharge) dischargeBelowLimit() bool {
+	return d.belowLimit == BelowLimitDischarge && d.startedBelowLimit()
+}
+
+// chargeBelowLimit tells whether the worker charges in a window that started below the SoC limit:
+// until the charge target is reached, unless the operator overrides the schedule or the grid is islanded.
+func (d *Discharge) chargeBelowLimit() bool {
+	if d.belowLimit != BelowLimitCharge || d.override != overrideNone || d.holdUntilWindow {
+		return false
+	}
+	if d.islandDetect != nil && d.islandDetect.IsIslanded() {
+		return false
+	}
+	return d.isTimeToDischarge() && d.startedBelowLimit() && d.status.RSOC < d.chargeTargetSoC()
+}
+
+// chargeTargetSoC returns the SoC charged to in a window that started below the limit; without
+// a target set, the battery is charged back to the limit.
+func (d *Discharge) chargeTargetSoC() float64 {
+	if d.chargeTarget > 0 {
+		return d.chargeTarget
+	}
+	return d.socLimit
+}
+
+// controlCharge starts or stops charging in a window that started below the SoC limit.
+func (d *Discharge) controlCharge() {
+	if d.chargeBelowLimit() {
+		d.runCharge()
+		return
+	}
+	if !d.isCharging {
+		return
+	}
+	log := d.log.With(
+		slog.Float64("SoC", d.status.RSOC),
+		slog.Float64("target", d.chargeTargetSoC()),
+	)
+	if d.status.RSOC >= d.chargeTargetSoC() {
+		d.logEvent(log, EventSessionStop, "charge target reached, stopping charge")
+	} else {
+		d.logEvent(log, EventSessionStop, "stopping charge")
+	}
+	err := d.stopCharge()
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("stopping charge")
+		observers.AddClientError(d.name, "stop_charge")
+	}
+}
+
+// runCharge switches the battery to manual mode and starts charging with the power limit, capped at
+// the maximum charge rate of the battery specification, if one is set.
+func (d *Discharge) runCharge() {
+	if d.isCharging {
+		return
+	}
+	power := d.powerLimit
+	if d.spec != nil && d.spec.MaxChargeRateW > 0 && float64(power) > d.spec.MaxChargeRateW {
+		power = int(d.spec.MaxChargeRateW)
+	}
+	d.logEvent(d.log.With(
+		slog.Float64("SoC", d.status.RSOC),
+		slog.Int("power", power),
+	), EventSessionStart, "starting charge")
+
+	err := d.setOperatingMode(entity.Manual)
+	if err == nil {
+		err = d.client.StartCharge(power)
+	}
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("starting charge")
+		observers.AddClientError(d.name, "start_charge")
+		return
+	}
+	d.isCharging = true
+}
+
+// stopCharge stops charging, if it is ongoing, and restores automatic mode. The battery is not
+// discharged in the rest of the window.
+func (d *Discharge) stopCharge() error {
+	if !d.isCharging {
+		return nil
+	}
+	err := d.client.StopCharge()
+	if err != nil {
+		return err
+	}
+	err = d.setOperatingMode(entity.Automatic)
+	if err != nil {
+		return err
+	}
+	d.isCharging = false
+	if d.isTimeToDischarge() {
+		d.holdUntilWindow = true
+	}
+	return nil
+}
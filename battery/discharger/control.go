@@ -0,0 +1,210 @@
+package discharger
+
+import (
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"time"
+)
+
+const commandQueueSize = 4
+
+type command int
+
+const (
+	commandForceStart command = iota
+	commandForceStop
+)
+
+// override is a manual decision of the operator that takes precedence over the schedule.
+type override int
+
+const (
+	overrideNone override = iota
+	// overrideStart keeps discharging outside the time window until the limits are reached
+	overrideStart
+	// overrideStop suppresses discharge until the end of the current time window
+	overrideStop
+)
+
+// State is a snapshot of the discharger, safe to read from other goroutines.
+type State struct {
+	Name          string               `json:"name"`
+	StartTime     string               `json:"start_time"`
+	StopTime      string               `json:"stop_time"`
+	IsDischarging bool                 `json:"is_discharging"`
+	Forced        bool                 `json:"forced"`
+	Status        *entity.SystemStatus `json:"status"`
+	Inhibited     *DischargeInhibited  `json:"inhibited,omitempty"`
+}
+
+func (d *Discharge) Name() string {
+	return d.name
+}
+
+// State returns the state published after the last status check or command.
+func (d *Discharge) State() State {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	state := d.state
+	if state.Status != nil {
+		status := *state.Status
+		state.Status = &status
+	}
+	return state
+}
+
+// ForceStart starts discharge now, regardless of the time window; limits are still respected.
+// While the grid is islanded or an inhibit signal is active, the start is deferred until it is released.
+// It fails if the worker cannot take more commands.
+func (d *Discharge) ForceStart() error {
+	return d.sendCommand(commandForceStart)
+}
+
+// ForceStop stops discharge now and keeps it stopped until the current time window ends.
+// It fails if the worker cannot take more commands.
+func (d *Discharge) ForceStop() error {
+	return d.sendCommand(commandForceStop)
+}
+
+// Reset sends a soft reset to the battery management system. It is a manual operator action only;
+// the worker never resets the battery on its own. It is safe to call while Run is active.
+func (d *Discharge) Reset() error {
+	d.log.Warn("battery reset requested")
+	return d.client.Reset()
+}
+
+func (d *Discharge) handleCommand(cmd command) {
+	switch cmd {
+	case commandForceStart:
+		d.logEvent(d.log, EventControl, "forced discharge start")
+		d.override = overrideStart
+		// never feed an islanded grid; the override takes effect once the grid is back
+		if d.islandDetect != nil && d.islandDetect.IsIslanded() {
+			d.log.Warn("grid islanded, forced discharge start deferred")
+			return
+		}
+		if d.inhibit {
+			d.logEvent(d.log, EventControl, "inhibit signal active, forced discharge start deferred")
+			return
+		}
+		if d.isReadyToDischarge() {
+			d.runDischarge()
+		}
+	case commandForceStop:
+		d.logEvent(d.log, EventControl, "forced discharge stop")
+		d.override = overrideStop
+		d.cancelRequests()
+		if err := d.stopCharge(); err != nil {
+			d.log.With(sl.Err(err)).Error("stopping charge")
+		}
+		d.stopWithReason(stopReasonForced)
+	}
+}
+
+// handleInhibit applies an inhibit signal: while inhibited, an active discharge is stopped and no session
+// starts; the inhibit is published in the worker state. Releasing it resumes normal scheduling.
+// It is kept apart from the safety check result, which is cleared whenever the checks pass.
+func (d *Discharge) handleInhibit(inhibit bool) {
+	if inhibit == d.inhibit {
+		return
+	}
+	d.inhibit = inhibit
+	if !inhibit {
+		d.logEvent(d.log, EventControl, "inhibit signal released")
+		d.signalInhibit = nil
+		return
+	}
+	d.logEvent(d.log, EventControl, "inhibit signal received")
+	d.signalInhibit = &DischargeInhibited{
+		Time:   time.Now(),
+		Reason: stopReasonInhibit,
+	}
+	if d.isDischarging {
+		d.stopWithReason(stopReasonInhibit)
+	}
+}
+
+// shouldDischarge applies the operator override, if any, on top of the schedule and limits.
+// Discharge is never allowed while the grid is islanded.
+// An override is cleared once it has no further effect.
+func (d *Discharge) shouldDischarge() bool {
+	if d.islandDetect != nil && d.islandDetect.IsIslanded() {
+		if d.isDischarging {
+			d.log.Warn("grid islanded, stopping discharge")
+			d.stopReason = stopReasonIslanded
+		}
+		return false
+	}
+	if d.inhibit {
+		return false
+	}
+	if d.externalRequestActive() {
+		return true
+	}
+	switch d.override {
+	case overrideStart:
+		if d.isReadyToDischarge() {
+			return true
+		}
+		d.logEvent(d.log, EventControl, "forced discharge reached the limit")
+		d.override = overrideNone
+		return false
+	case overrideStop:
+		if d.isTimeToDischarge() {
+			return false
+		}
+		d.override = overrideNone
+	}
+	// a window charged because it started below the limit is not discharged
+	if d.isCharging {
+		return false
+	}
+	// a session stopped by a limit or stop condition is not resumed within the same window
+	if d.holdUntilWindow {
+		if d.isTimeToDischarge() || d.isInEarlyStartWindow() {
+			return false
+		}
+		d.holdUntilWindow = false
+	}
+	if d.isEarlyStart() && d.isReadyToDischarge() {
+		if !d.isDischarging {
+			d.logEvent(d.log.With(
+				slog.Float64("SoC", d.status.RSOC),
+				slog.Float64("threshold", d.earlyStartSoC),
+				slog.String("start_time", d.startTime),
+			), EventSchedule, "early start: SoC above threshold before scheduled start")
+		}
+		return true
+	}
+	if !d.isTimeToDischarge() {
+		return false
+	}
+	return d.isReadyToDischarge() || d.dischargeBelowLimit()
+}
+
+// publishState stores the state returned by State; the status is cloned since API handlers read it concurrently.
+func (d *Discharge) publishState() {
+	// an inhibit signal takes precedence over a failed safety check in the published state
+	inhibited := d.inhibited
+	if d.signalInhibit != nil {
+		inhibited = d.signalInhibit
+	}
+	var status *entity.SystemStatus
+	if d.status != nil {
+		clone := d.status.Clone()
+		status = &clone
+	}
+	d.heartbeat()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.state = State{
+		Name:          d.name,
+		StartTime:     d.startTime,
+		StopTime:      d.stopTime,
+		IsDischarging: d.isDischarging,
+		Forced:        d.override != overrideNone,
+		Status:        status,
+		Inhibited:     inhibited,
+	}
+}
@@ -0,0 +1,96 @@
+package discharger
+
+import (
+	"context"
+	"errors"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"time"
+)
+
+// variables rather than constants, so tests need not wait for them
+var (
+	// clientTimeout matches the request timeout of the battery API client
+	clientTimeout = 5 * time.Second
+	// deadlockTimeout is how long the worker loop may show no progress with a full command queue
+	deadlockTimeout = 5 * clientTimeout
+)
+
+var (
+	ErrCommandQueueFull = errors.New("command queue is full")
+	ErrWorkerStuck      = errors.New("discharge worker is not responding")
+)
+
+// heartbeat records progress of the worker loop; long-running steps, like ramps, call it between steps.
+func (d *Discharge) heartbeat() {
+	d.mutex.Lock()
+	d.activity = time.Now()
+	d.stuck = false
+	d.mutex.Unlock()
+}
+
+// detectDeadlock watches the worker loop until ctx is done. If the command queue is full and the loop
+// has shown no progress for deadlockTimeout, it is stuck, e.g. on a client call that never returns.
+// The queued commands are then rejected and further commands fail with ErrWorkerStuck until the loop
+// makes progress again. With WithDeadlockShutdown, the deadlock is logged at FATAL level and the daemon
+// is shut down, so a supervisor can restart it; otherwise the process keeps running, so the loop can
+// still restore automatic mode.
+func (d *Discharge) detectDeadlock(ctx context.Context) {
+	ticker := time.NewTicker(clientTimeout)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.mutex.Lock()
+			idle := time.Since(d.activity)
+			stuck := len(d.commands) == cap(d.commands) && idle >= deadlockTimeout
+			detected := stuck && !d.stuck
+			if stuck {
+				d.stuck = true
+			}
+			d.mutex.Unlock()
+			if stuck {
+				d.rejectCommands(idle)
+			}
+			if detected && d.onDeadlock != nil {
+				d.log.With(slog.Duration("idle", idle)).Log(ctx, sl.LevelFatal, "discharge worker deadlocked, shutting down")
+				d.onDeadlock()
+			}
+		}
+	}
+}
+
+// rejectCommands drops the queued commands of a stuck worker.
+func (d *Discharge) rejectCommands(idle time.Duration) {
+	rejected := 0
+	for {
+		select {
+		case <-d.commands:
+			rejected++
+		default:
+			d.log.With(
+				slog.Int("rejected_commands", rejected),
+				slog.Duration("idle", idle),
+			).Error("discharge worker not responding, queued commands rejected")
+			return
+		}
+	}
+}
+
+// sendCommand queues a command for the worker loop without blocking the caller.
+func (d *Discharge) sendCommand(cmd command) error {
+	d.mutex.Lock()
+	stuck := d.stuck
+	d.mutex.Unlock()
+	if stuck {
+		return ErrWorkerStuck
+	}
+	select {
+	case d.commands <- cmd:
+		return nil
+	default:
+		return ErrCommandQueueFull
+	}
+}
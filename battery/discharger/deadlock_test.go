@@ -0,0 +1,69 @@
+package discharger
+
+import (
+	"context"
+	"gok-pi/battery/client/mock"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/testutil"
+	"testing"
+	"time"
+)
+
+// blockingClient never returns from StartDischarge until released.
+type blockingClient struct {
+	*mock.MockClient
+	release chan struct{}
+}
+
+func (c *blockingClient) StartDischarge(power int) error {
+	<-c.release
+	return c.MockClient.StartDischarge(power)
+}
+
+func TestDeadlockShutdown(t *testing.T) {
+	clientTimeout, deadlockTimeout = 10*time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() {
+		clientTimeout, deadlockTimeout = 5*time.Second, 25*time.Second
+	})
+
+	client := &blockingClient{MockClient: mock.New(), release: make(chan struct{})}
+	client.SetStatusSequence([]entity.SystemStatus{{
+		OperatingMode:       string(entity.Automatic),
+		RSOC:                80,
+		RemainingCapacityWh: 8000,
+	}})
+	shutdown := make(chan struct{})
+	worker, err := New("home", client, testutil.NewTestLogger(t), WithDeadlockShutdown(func() {
+		close(shutdown)
+	}))
+	if err != nil {
+		t.Fatalf("creating worker: %v", err)
+	}
+	now := time.Now()
+	worker.SetTime(now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04"))
+	worker.SetLimits(1000, 2000, 20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- worker.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		close(client.release)
+		<-done
+	}()
+
+	// the loop is stuck starting discharge; fill the command queue
+	for i := 0; i < commandQueueSize; i++ {
+		if err = worker.ForceStop(); err != nil {
+			t.Fatalf("queueing command %d: %v", i, err)
+		}
+	}
+	select {
+	case <-shutdown:
+	case <-time.After(testutil.WaitTimeout):
+		t.Fatal("timeout waiting for the deadlock shutdown")
+	}
+	testutil.AssertErrorIs(t, worker.ForceStart(), ErrWorkerStuck)
+}
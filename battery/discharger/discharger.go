@@ -1,47 +1,168 @@
 package discharger
 
 import (
+	"context"
+	"fmt"
 	"gok-pi/battery/entity"
+	"gok-pi/battery/scheduler"
+	"gok-pi/battery/storage"
+	"gok-pi/battery/tariff"
 	"gok-pi/internal/lib/sl"
 	"gok-pi/internal/lib/timer"
 	"gok-pi/metrics/observers"
 	"log/slog"
+	"math/rand"
+	"sync"
 	"time"
 )
 
+const (
+	checkInterval    = 10 * time.Second
+	meterInterval    = time.Minute
+	earlyStartWindow = 2 * time.Hour
+)
+
 type Client interface {
 	Status() (*entity.SystemStatus, error)
+	EnergyMeters() (*entity.EnergyMeterSnapshot, error)
+	DailyStats() (*entity.DailyBatteryStats, error)
 	StartDischarge(power int) error
 	StopDischarge() error
-	SwitchOperatingModeToManual(currentMode string) error
-	SwitchOperatingModeToAuto(currentMode string) error
+	StartCharge(power int) error
+	StopCharge() error
+	SetOperatingMode(mode entity.OperatingMode) error
+	Reset() error
+	// Ping checks that the battery can be reached, without reading its status.
+	Ping() error
+}
+
+// HolidayCalendar tells whether a day is a public holiday, when scheduled discharge is skipped.
+type HolidayCalendar interface {
+	IsHoliday(t time.Time) bool
+}
+
+// CarbonSource provides the carbon intensity of grid electricity in gCO2/kWh at a given time.
+type CarbonSource interface {
+	IntensityAt(t time.Time) (float64, error)
+}
+
+// MetricsObserver receives every battery status reading of the worker.
+type MetricsObserver interface {
+	UpdateAll(name string, status *entity.SystemStatus)
+}
+
+// MetricsObserverFunc adapts a function to the MetricsObserver interface.
+type MetricsObserverFunc func(name string, status *entity.SystemStatus)
+
+func (f MetricsObserverFunc) UpdateAll(name string, status *entity.SystemStatus) {
+	f(name, status)
+}
+
+// IslandDetector tells whether the inverter runs without grid connection, based on observed readings.
+type IslandDetector interface {
+	Observe(frequency, voltage float64)
+	IsIslanded() bool
 }
 
 type Discharge struct {
-	name          string
-	startTime     string
-	stopTime      string
-	capacityLimit float64
-	powerLimit    int
-	socLimit      float64
-	isDischarging bool
-	client        Client
-	status        *entity.SystemStatus
-	log           *slog.Logger
-}
-
-func New(name string, client Client, log *slog.Logger) (*Discharge, error) {
-	return &Discharge{
-		name:   name,
-		client: client,
-		log:    log.With(sl.Module("battery.discharge")),
-	}, nil
+	name            string
+	startTime       string
+	stopTime        string
+	capacityLimit   float64
+	powerLimit      int
+	socLimit        float64
+	configLimit     float64
+	dynamicLimit    func(time.Time) float64
+	schedule        *scheduler.Scheduler
+	belowLimit      BelowLimitBehaviour
+	belowLimitStart bool
+	belowLimitAt    time.Time
+	chargeTarget    float64
+	isCharging      bool
+	limitWindow     time.Time
+	isDischarging   bool
+	client          Client
+	status          *entity.SystemStatus
+	exportStore     ExportStore
+	tariffSource    tariff.TariffSource
+	carbonSource    CarbonSource
+	holidays        HolidayCalendar
+	holidaySkipped  time.Time
+	eventStore      storage.EventStore
+	recent          *entity.SlidingWindow[entity.BatterySnapshot]
+	islandDetect    IslandDetector
+	metrics         MetricsObserver
+	hooks           []DischargeHook
+	safetyChecks    []SafetyCheck
+	inhibited       *DischargeInhibited
+	statusThreshold float64
+	logLevels       map[EventType]slog.Level
+	statusLogged    bool
+	loggedSoC       float64
+	midWindowStart  bool
+	inhibitSignal   <-chan bool
+	inhibit         bool
+	signalInhibit   *DischargeInhibited
+	startTrigger    <-chan DischargeRequest
+	request         *DischargeRequest
+	requestQueue    []DischargeRequest
+	scheduleStore   ScheduleStore
+	savedWindow     *timer.TimeRange
+	persistedWindow timer.TimeRange
+	spec            *entity.BatterySpec
+	metersRead      time.Time
+	dailyStats      *entity.DailyBatteryStats
+	dailyCostSaved  map[string]float64
+	reserveSoC      float64
+	maxEnergyWh     float64
+	holdUntilWindow bool
+	stopConditions  []StopCondition
+	stopReason      string
+	maxJitter       time.Duration
+	earlyStartSoC   float64
+	softStart       ramp
+	softStop        ramp
+	deferred        []func()
+	runDone         <-chan struct{}
+	power           int
+	jitter          time.Duration
+	jitterBase      time.Time
+	dispatchTime    time.Duration
+	session         *entity.SessionSummary
+	lastSession     *entity.SessionSummary
+	override        override
+	commands        chan command
+	state           State
+	activity        time.Time
+	stuck           bool
+	onDeadlock      context.CancelFunc
+	mutex           sync.Mutex
+	log             *slog.Logger
+}
+
+func New(name string, client Client, log *slog.Logger, opts ...Option) (*Discharge, error) {
+	d := &Discharge{
+		name:           name,
+		client:         client,
+		commands:       make(chan command, commandQueueSize),
+		recent:         entity.NewSlidingWindow[entity.BatterySnapshot](recentSnapshots),
+		dispatchTime:   defaultDispatchTime,
+		metrics:        MetricsObserverFunc(observers.UpdateAll),
+		hooks:          []DischargeHook{MetricsHook{}},
+		midWindowStart: true,
+		log:            log.With(sl.Module("battery.discharge"), slog.String("battery", name)),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d, nil
 }
 
 func (d *Discharge) SetLimits(capacityLimit, powerLimit, socLimit int) {
 	d.capacityLimit = float64(capacityLimit)
 	d.powerLimit = powerLimit
 	d.socLimit = float64(socLimit)
+	d.configLimit = d.socLimit
 }
 
 func (d *Discharge) SetTime(startTime, stopTime string) {
@@ -49,33 +170,142 @@ func (d *Discharge) SetTime(startTime, stopTime string) {
 	d.stopTime = stopTime
 }
 
-func (d *Discharge) Run() error {
-	ticker := time.NewTicker(10 * time.Second)
+// Run monitors the battery and controls discharge until the context is cancelled.
+// An ongoing discharge is stopped before returning, so the battery is left in automatic mode.
+// The battery is checked right away, so a worker started within the discharge window starts
+// discharging immediately, unless mid-window start is disabled.
+// Commands sent to a stuck worker loop are rejected, see detectDeadlock.
+func (d *Discharge) Run(ctx context.Context) error {
+	ticker := time.NewTicker(checkInterval)
 	defer ticker.Stop()
 
+	detectCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	d.runDone = ctx.Done()
+	d.publishState()
+	go d.detectDeadlock(detectCtx)
+
+	d.restoreSchedule()
+	if !d.midWindowStart && d.isTimeToDischarge() {
+		d.logEvent(d.log, EventSchedule, "started within the discharge window, waiting for the next one")
+		d.holdUntilWindow = true
+	}
+	d.monitorState(ctx)
+	d.runDeferred()
+	d.publishState()
+
 	for {
 		select {
-		case <-ticker.C:
-			status, err := d.client.Status()
+		case <-ctx.Done():
+			d.stopReason = stopReasonShutdown
+			if err := d.stopCharge(); err != nil {
+				return fmt.Errorf("stopping charge: %w", err)
+			}
+			err := d.stopDischarge()
 			if err != nil {
-				d.log.With(sl.Err(err)).Error("checking battery status")
+				return fmt.Errorf("stopping discharge: %w", err)
+			}
+			return nil
+		case <-ticker.C:
+			d.monitorState(ctx)
+		case cmd := <-d.commands:
+			d.handleCommand(cmd)
+		case inhibit, ok := <-d.inhibitSignal:
+			if !ok {
+				d.inhibitSignal = nil
 				continue
 			}
-			d.status = status
-			d.observeStatus()
-
-			if d.isTimeToDischarge() && d.isReadyToDischarge() {
-				d.runDischarge()
-			} else {
-				err = d.stopDischarge()
-				if err != nil {
-					d.log.With(sl.Err(err)).Error("stopping discharge")
-				}
+			d.handleInhibit(inhibit)
+		case request, ok := <-d.startTrigger:
+			if !ok {
+				d.startTrigger = nil
+				continue
 			}
+			d.handleStartRequest(request)
+		}
+		d.runDeferred()
+		d.publishState()
+	}
+}
+
+// monitorState reads the battery status and starts or stops discharge according to the schedule and limits.
+func (d *Discharge) monitorState(ctx context.Context) {
+	status, err := d.client.Status()
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("checking battery status")
+		observers.AddClientError(d.name, "status")
+		return
+	}
+	d.status = status
+	if err = status.Validate(); err != nil {
+		d.log.With(sl.Err(err)).Warn("checking battery status")
+	}
+	d.observeStatus()
+	d.logStatus(false)
+	d.trackExport()
+	d.trackSession()
+	d.saveSnapshot()
+	if d.islandDetect != nil {
+		d.islandDetect.Observe(status.Fac, status.Uac)
+	}
+	d.detectExternalStop()
+	d.checkStopConditions(ctx)
+	d.controlCharge()
+
+	if d.shouldDischarge() {
+		d.runDischarge()
+	} else if d.isDischarging {
+		if d.stopReason == "" {
+			d.stopReason = d.limitOrScheduleStop()
 		}
+		d.stopWithReason(d.stopReason)
 	}
 }
 
+// limitOrScheduleStop logs why a session that is no longer due stops: the battery reached its limit
+// or the window ended first, and returns the matching stop reason.
+func (d *Discharge) limitOrScheduleStop() string {
+	log := d.log.With(
+		slog.Float64("SoC", d.status.RSOC),
+		slog.Float64("limit", d.socLimit),
+	)
+	// a window that started below the limit may be discharged to its end regardless of the limit
+	belowLimitSession := d.belowLimitStart && d.belowLimit == BelowLimitDischarge
+	if !d.isReadyToDischarge() && !belowLimitSession {
+		d.logEvent(log, EventSessionStop, "battery level reached the limit, stopping discharge")
+		return stopReasonSoC
+	}
+	d.logEvent(log, EventSessionStop, "stop time reached before the SoC limit, stopping discharge")
+	return stopReasonSchedule
+}
+
+// detectExternalStop handles a discharge stopped by the inverter itself, e.g. on a grid fault or BMS protection.
+// The session is closed without sending a stop command, the battery is returned to automatic mode
+// and discharge is not restarted until the next scheduled window.
+// The inverter may need a moment to start discharging, so a session younger than two checks is not verified.
+func (d *Discharge) detectExternalStop() {
+	if !d.isDischarging || d.status == nil || d.status.DischargeActive() {
+		return
+	}
+	if d.session != nil && time.Since(d.session.StartTime) < 2*checkInterval {
+		return
+	}
+	d.log.With(
+		slog.Float64("SoC", d.status.RSOC),
+		slog.Float64("pac", d.status.PacTotalW),
+	).Warn("discharge stopped externally")
+
+	err := d.setOperatingMode(entity.Automatic)
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("switching operating mode")
+	}
+	d.isDischarging = false
+	d.holdUntilWindow = true
+	d.override = overrideNone
+	d.stopReason = stopReasonExternal
+	d.closeSession()
+}
+
 // isReadyToDischarge checks if the battery is ready to start discharging based on status, remaining capacity, and SoC limits.
 func (d *Discharge) isReadyToDischarge() bool {
 	return d.status != nil && d.status.RemainingCapacityWh > d.capacityLimit && d.status.RSOC > d.socLimit
@@ -83,22 +313,146 @@ func (d *Discharge) isReadyToDischarge() bool {
 
 // isTimeToDischarge determines whether the current time falls within the specified discharge time window.
 func (d *Discharge) isTimeToDischarge() bool {
-	// Calculate the start and stop times for today
-	startTime, err := timer.ParseTime(d.startTime)
-	if err != nil {
-		d.log.With(sl.Err(err)).Error("parsing start time")
-		return false
+	// Go time ignores leap seconds: a leap second is absorbed by the system clock, which the time package
+	// sees as an ordinary clock adjustment, and Duration arithmetic never counts it. The window bounds
+	// are wall clock times, so a comparison can be off by at most one second on a leap second day.
+	now := time.Now()
+	window, ok := d.dischargeWindow(now)
+	// with soft stop, the ramp begins early enough to reach zero power at the scheduled stop time
+	if d.softStop.enabled() {
+		window.Stop = window.Stop.Add(-d.softStop.duration)
 	}
-	stopTime, err := timer.ParseTime(d.stopTime)
-	if err != nil {
-		d.log.With(sl.Err(err)).Error("parsing stop time")
+	return ok && window.Contains(now) && !d.isHoliday(window)
+}
+
+// isHoliday tells whether the window starts on a holiday of the holiday calendar, if one is set.
+// The skipped session is logged once per window.
+func (d *Discharge) isHoliday(window timer.TimeRange) bool {
+	if d.holidays == nil || !d.holidays.IsHoliday(window.Start) {
 		return false
 	}
-	if startTime.After(stopTime) {
-		stopTime = stopTime.Add(24 * time.Hour)
+	if !d.holidaySkipped.Equal(window.Start) {
+		d.holidaySkipped = window.Start
+		d.logEvent(d.log.With(slog.Time("start_time", window.Start)), EventSchedule, "holiday, discharge skipped")
+	}
+	return true
+}
+
+// isInEarlyStartWindow tells whether early start is enabled and now is within earlyStartWindow before the scheduled start.
+func (d *Discharge) isInEarlyStartWindow() bool {
+	if d.earlyStartSoC <= 0 {
+		return false
 	}
 	now := time.Now()
-	return now.After(startTime) && now.Before(stopTime)
+	window, ok := d.dischargeWindow(now)
+	early := timer.TimeRange{Start: window.Start.Add(-earlyStartWindow), Stop: window.Start}
+	return ok && early.Contains(now) && !d.isHoliday(window)
+}
+
+// isEarlyStart tells whether discharge should start ahead of schedule because the battery is nearly full;
+// a discharge started early keeps running into the scheduled window.
+func (d *Discharge) isEarlyStart() bool {
+	if d.status == nil || !d.isInEarlyStartWindow() {
+		return false
+	}
+	return d.isDischarging || d.status.RSOC >= d.earlyStartSoC
+}
+
+// dischargeWindow returns the window that is active at now or, if none is, the window starting later today.
+// With a scheduler, it returns the active or next scheduled window instead.
+// ok is false if the schedule cannot be parsed or the scheduler has no window left.
+func (d *Discharge) dischargeWindow(now time.Time) (timer.TimeRange, bool) {
+	if d.savedWindow != nil {
+		if now.Before(d.savedWindow.Stop) {
+			d.applyDynamicLimit(*d.savedWindow)
+			return *d.savedWindow, true
+		}
+		d.savedWindow = nil
+	}
+	if d.schedule != nil {
+		return d.scheduledWindow(now)
+	}
+	window, err := timer.ParseTimeRange(d.startTime, d.stopTime)
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("parsing discharge window")
+		return timer.TimeRange{}, false
+	}
+	// a window spanning midnight is still active after midnight if it started yesterday
+	if window.IsOvernight() {
+		yesterday := timer.TimeRange{Start: window.Start.AddDate(0, 0, -1), Stop: window.Stop.AddDate(0, 0, -1)}
+		if now.Before(yesterday.Stop) {
+			window = yesterday
+		}
+	}
+	window.Start = d.jitteredStart(window.Start)
+	d.persistSchedule(window)
+	d.applyDynamicLimit(window)
+	return window, true
+}
+
+// scheduledWindow returns the window of the scheduler that is active at now or, if none is, the next one.
+// The SoC limit of the window applies to its session; a window without a limit keeps the worker's limit.
+func (d *Discharge) scheduledWindow(now time.Time) (timer.TimeRange, bool) {
+	scheduled, ok := d.schedule.Active(now)
+	if !ok {
+		scheduled, ok = d.schedule.Next(now)
+	}
+	if !ok {
+		return timer.TimeRange{}, false
+	}
+	window := scheduled.TimeRange
+	window.Start = d.jitteredStart(window.Start)
+	d.persistSchedule(window)
+	if !d.limitWindow.Equal(window.Start) {
+		limit := scheduled.SocLimit
+		if limit == 0 {
+			limit = d.configLimit
+			if d.dynamicLimit != nil {
+				limit = d.dynamicLimit(scheduled.Start)
+			}
+		}
+		d.setWindowLimit(window, limit)
+	}
+	return window, true
+}
+
+// applyDynamicLimit sets the SoC limit computed for the window start, once per window.
+func (d *Discharge) applyDynamicLimit(window timer.TimeRange) {
+	if d.dynamicLimit == nil || d.limitWindow.Equal(window.Start) {
+		return
+	}
+	d.setWindowLimit(window, d.dynamicLimit(window.Start))
+}
+
+// setWindowLimit replaces the SoC limit for the window.
+func (d *Discharge) setWindowLimit(window timer.TimeRange, limit float64) {
+	d.limitWindow = window.Start
+	if limit == d.socLimit {
+		return
+	}
+	d.logEvent(d.log.With(
+		slog.Time("start_time", window.Start),
+		slog.Float64("previous", d.socLimit),
+		slog.Float64("limit", limit),
+	), EventSchedule, "SoC limit updated for the discharge window")
+	d.socLimit = limit
+}
+
+// jitteredStart delays the scheduled start by a random duration up to maxJitter, so that many batteries
+// sharing a schedule do not start at the same moment. The delay is drawn once per scheduled start.
+func (d *Discharge) jitteredStart(startTime time.Time) time.Time {
+	if d.maxJitter <= 0 {
+		return startTime
+	}
+	if !startTime.Equal(d.jitterBase) {
+		d.jitterBase = startTime
+		d.jitter = time.Duration(rand.Int63n(int64(d.maxJitter) + 1))
+		d.logEvent(d.log.With(
+			slog.Time("start_time", startTime),
+			slog.Time("jittered_start_time", startTime.Add(d.jitter)),
+		), EventSchedule, "start time jitter applied")
+	}
+	return startTime.Add(d.jitter)
 }
 
 // runDischarge manages the discharge process of the battery based on its current status and predefined limits.
@@ -115,31 +469,84 @@ func (d *Discharge) runDischarge() {
 	)
 
 	if d.isDischarging {
-		if !d.isReadyToDischarge() {
-			log.Info("battery level reached the limit, stopping discharge")
-			err := d.stopDischarge()
-			if err != nil {
-				d.log.With(sl.Err(err)).Error("stopping discharge")
-				return
-			}
+		if !d.isReadyToDischarge() && !d.dischargeBelowLimit() {
+			d.logEvent(log, EventSessionStop, "battery level reached the limit, stopping discharge")
+			d.stopWithReason(stopReasonSoC)
+		} else if d.isSessionEnergyReached() {
+			d.logEvent(log.With(
+				slog.String("reason", stopReasonEnergy),
+				slog.Float64("energy_wh", d.session.EnergyWh),
+			), EventSessionStop, "session energy reached the limit, stopping discharge")
+			d.holdUntilWindow = true
+			d.override = overrideNone
+			d.stopWithReason(stopReasonEnergy)
 		}
 		return
 	}
 
-	err := d.client.SwitchOperatingModeToManual(d.status.OperatingMode)
-	if err != nil {
-		d.log.With(sl.Err(err)).Error("switching operating mode")
+	if !d.checkSafety() {
 		return
 	}
-
-	log.Info("starting discharge")
-	err = d.client.StartDischarge(d.powerLimit)
+	d.logEvent(log, EventSessionStart, "starting discharge")
+	err := d.startDischarge(d.powerLimit)
 	if err != nil {
 		d.log.With(sl.Err(err)).Error("starting discharge")
-		return
+		observers.AddClientError(d.name, "start_discharge")
+	}
+}
+
+// startDischarge switches the battery to manual mode and starts discharging with the given power.
+// The power is capped at the maximum discharge rate of the battery specification, if one is set.
+func (d *Discharge) startDischarge(power int) error {
+	if d.spec != nil && d.spec.MaxDischargeRateW > 0 && float64(power) > d.spec.MaxDischargeRateW {
+		d.log.With(
+			slog.Int("power", power),
+			slog.Float64("max_rate", d.spec.MaxDischargeRateW),
+		).Warn("discharge power limited by battery specification")
+		power = int(d.spec.MaxDischargeRateW)
+	}
+	err := d.stopCharge()
+	if err != nil {
+		return fmt.Errorf("stopping charge: %w", err)
+	}
+	err = d.beforeStart()
+	if err != nil {
+		return err
+	}
+	err = d.setOperatingMode(entity.Manual)
+	if err != nil {
+		return fmt.Errorf("switching operating mode: %w", err)
+	}
+	reached, err := d.rampUp(power)
+	if err != nil {
+		return err
 	}
 	d.isDischarging = true
+	d.power = reached
+	d.startSession()
+	if d.exportStore != nil {
+		d.exportStore.Reset()
+	}
+	return nil
+}
+
+// setOperatingMode switches the inverter to the given mode, unless the last status reports it already.
+// Manual mode keeps the inverter from overriding the discharge with its own logic.
+func (d *Discharge) setOperatingMode(mode entity.OperatingMode) error {
+	if d.status != nil && entity.OperatingMode(d.status.OperatingMode) == mode {
+		return nil
+	}
+	return d.client.SetOperatingMode(mode)
+}
 
+// stopWithReason stops discharge and records the reason in the session summary; errors are logged.
+func (d *Discharge) stopWithReason(reason string) {
+	d.stopReason = reason
+	err := d.stopDischarge()
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("stopping discharge")
+		observers.AddClientError(d.name, "stop_discharge")
+	}
 }
 
 // stopDischarge stops the current discharge activity if it is ongoing.
@@ -147,19 +554,19 @@ func (d *Discharge) runDischarge() {
 func (d *Discharge) stopDischarge() error {
 	if d.isDischarging {
 
-		err := d.client.StopDischarge()
+		err := d.rampDown()
 		if err != nil {
 			return err
 		}
 
-		if d.status != nil {
-			err = d.client.SwitchOperatingModeToAuto(d.status.OperatingMode)
-			if err != nil {
-				return err
-			}
+		// restore automatic mode, so the inverter schedule does not stay overridden
+		err = d.setOperatingMode(entity.Automatic)
+		if err != nil {
+			return err
 		}
 
 		d.isDischarging = false
+		d.closeSession()
 	}
 	return nil
 }
@@ -170,7 +577,7 @@ func (d *Discharge) stopDischarge() error {
 //	if estimate <= 0 {
 //		return 0
 //	}
-//	remainingTime := stopTime.Sub(time.Now())
+//	remainingTime := time.Until(stopTime)
 //	if remainingTime <= 0 {
 //		return 0
 //	}
@@ -181,18 +588,79 @@ func (d *Discharge) stopDischarge() error {
 //	return int(rate)
 //}
 
-// observeStatus updates various battery status metrics through external observers.
+// observeStatus passes the battery status to the metrics observer, on every status check.
 // If the status is nil, the method returns immediately.
 func (d *Discharge) observeStatus() {
 	if d.status == nil {
 		return
 	}
-	go func(status *entity.SystemStatus) {
-		observers.UpdateSoC(d.name, status.RSOC)
-		observers.UpdateUSoC(d.name, status.USOC)
-		observers.UpdateCapacity(d.name, status.RemainingCapacityWh)
-		observers.UpdateConsumption(d.name, status.ConsumptionW)
-		observers.UpdatePac(d.name, status.PacTotalW)
-		observers.UpdateDischargeState(d.name, status.BatteryDischarging)
-	}(d.status)
+	if d.metrics != nil {
+		status := d.status.Clone()
+		go d.metrics.UpdateAll(d.name, &status)
+	}
+	d.observeMeters()
+}
+
+// observeMeters reads the energy meters and daily statistics in the background, at most once per meterInterval.
+// Not every inverter has the meters, so a failed read is logged at debug level only.
+func (d *Discharge) observeMeters() {
+	if time.Since(d.metersRead) < meterInterval {
+		return
+	}
+	d.metersRead = time.Now()
+	go func() {
+		meters, err := d.client.EnergyMeters()
+		if err != nil {
+			d.log.With(sl.Err(err)).Debug("reading energy meters")
+		} else {
+			observers.UpdateMeterGridImport(d.name, meters.GridImportKwh)
+			observers.UpdateMeterGridExport(d.name, meters.GridExportKwh)
+			observers.UpdateMeterSelfConsumed(d.name, meters.SelfConsumedKwh)
+			observers.UpdateMeterSolarGenerated(d.name, meters.SolarGeneratedKwh)
+		}
+		d.observeDailyStats()
+	}()
+}
+
+// observeDailyStats updates the daily statistics metrics and logs the summary of the previous day
+// once the statistics roll over to a new date.
+func (d *Discharge) observeDailyStats() {
+	stats, err := d.client.DailyStats()
+	if err != nil {
+		d.log.With(sl.Err(err)).Debug("reading daily stats")
+		return
+	}
+	observers.UpdateDailyStats(d.name, stats)
+
+	d.mutex.Lock()
+	previous := d.dailyStats
+	d.dailyStats = stats
+	d.mutex.Unlock()
+
+	if previous != nil && !previous.Date.Equal(stats.Date) {
+		date := previous.Date.Format(time.DateOnly)
+		d.logEvent(d.log.With(
+			slog.String("date", date),
+			slog.Int("min_soc", previous.MinSoC),
+			slog.Int("max_soc", previous.MaxSoC),
+			slog.Int("cycles", previous.CyclesStarted),
+			slog.Float64("charged_wh", previous.EnergyChargedWh),
+			slog.Float64("discharged_wh", previous.EnergyDischargedWh),
+			slog.Float64("cost_saved", d.takeDailyCostSaved(date)),
+		), EventDailySummary, "daily summary")
+	}
+}
+
+// takeDailyCostSaved returns the cost saved by the sessions started on date and forgets it, along with
+// any earlier day that had no summary.
+func (d *Discharge) takeDailyCostSaved(date string) float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	cost := d.dailyCostSaved[date]
+	for day := range d.dailyCostSaved {
+		if day <= date {
+			delete(d.dailyCostSaved, day)
+		}
+	}
+	return cost
 }
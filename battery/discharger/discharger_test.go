@@ -0,0 +1,192 @@
+package discharger_test
+
+import (
+	"context"
+	"gok-pi/battery/client/mock"
+	"gok-pi/battery/client/mock/mocktest"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/battery/scheduler"
+	"gok-pi/internal/lib/testutil"
+	"gok-pi/internal/lib/timer"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testPower = 2000
+
+// runWorker runs a worker against a mock client reading 80% SoC and a SoC limit of 20%, with a discharge
+// window from an hour ago to an hour from now, and returns once the first status check has been published.
+func runWorker(t *testing.T, opts ...discharger.Option) (*discharger.Discharge, *mock.MockClient) {
+	t.Helper()
+	worker, client, _ := startWorker(t, opts...)
+	testutil.WaitFor(t, "first status check", func() bool {
+		return worker.State().Status != nil
+	})
+	return worker, client
+}
+
+// startWorker starts a worker like runWorker without waiting for the first status check. The returned
+// function stops the worker and waits for Run to return; it is also called when the test ends.
+func startWorker(t *testing.T, opts ...discharger.Option) (*discharger.Discharge, *mock.MockClient, func()) {
+	t.Helper()
+	log := testutil.NewTestLogger(t)
+
+	client := mock.New()
+	client.SetStatusSequence([]entity.SystemStatus{{
+		OperatingMode:       string(entity.Automatic),
+		RSOC:                80,
+		RemainingCapacityWh: 8000,
+	}})
+
+	worker, err := discharger.New("home", client, log, opts...)
+	if err != nil {
+		t.Fatalf("creating worker: %v", err)
+	}
+	now := time.Now()
+	worker.SetTime(now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04"))
+	worker.SetLimits(1000, testPower, 20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- worker.Run(ctx)
+	}()
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("running worker: %v", err)
+			}
+		})
+	}
+	t.Cleanup(stop)
+	return worker, client, stop
+}
+
+func TestMidWindowStart(t *testing.T) {
+	worker, client := runWorker(t)
+	client.ExpectStartDischarge(1)
+
+	testutil.WaitFor(t, "discharge start", func() bool {
+		return worker.State().IsDischarging
+	})
+	if power := client.Power(); power != testPower {
+		t.Errorf("power %d W, want %d W", power, testPower)
+	}
+	mocktest.AssertExpectations(t, client)
+}
+
+func TestMidWindowStartDisabled(t *testing.T) {
+	worker, client := runWorker(t, discharger.WithMidWindowStart(false))
+	client.ExpectStartDischarge(0)
+
+	if worker.State().IsDischarging {
+		t.Error("worker started within the window discharges, want it to wait for the next window")
+	}
+	mocktest.AssertExpectations(t, client)
+}
+
+func TestSchedulerWindowLimit(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		limit     float64
+		discharge bool
+	}{
+		{"limit below SoC", 70, true},
+		{"limit above SoC", 85, false},
+		// the worker's limit of 20% applies
+		{"no limit", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := scheduler.New(nil)
+			schedule.SetLimitedWindows([]scheduler.Window{{
+				TimeRange: timer.TimeRange{Start: now.Add(-time.Hour), Stop: now.Add(time.Hour)},
+				SocLimit:  tt.limit,
+			}})
+			worker, client := runWorker(t, discharger.WithScheduler(schedule))
+			if !tt.discharge {
+				client.ExpectStartDischarge(0)
+				if worker.State().IsDischarging {
+					t.Error("discharging, want the window limit to prevent the session")
+				}
+				mocktest.AssertExpectations(t, client)
+				return
+			}
+			testutil.WaitFor(t, "discharge start", func() bool {
+				return worker.State().IsDischarging
+			})
+		})
+	}
+}
+
+func TestSchedulerEmpty(t *testing.T) {
+	worker, client := runWorker(t, discharger.WithScheduler(scheduler.New(nil)))
+	client.ExpectStartDischarge(0)
+
+	if worker.State().IsDischarging {
+		t.Error("discharging without a scheduled window")
+	}
+	mocktest.AssertExpectations(t, client)
+}
+
+func TestSoftStartForceStop(t *testing.T) {
+	worker, client, _ := startWorker(t, discharger.WithSoftStart(time.Hour, 2))
+	client.ExpectStartDischarge(1)
+	client.ExpectStopDischarge(1)
+
+	testutil.WaitFor(t, "first soft start step", func() bool {
+		return client.Power() == testPower/2
+	})
+	if err := worker.ForceStop(); err != nil {
+		t.Fatalf("forcing stop: %v", err)
+	}
+	testutil.WaitFor(t, "discharge stop during the ramp", func() bool {
+		return client.Calls("StopDischarge") > 0
+	})
+	testutil.WaitFor(t, "published state", func() bool {
+		state := worker.State()
+		return state.Status != nil && !state.IsDischarging
+	})
+	mocktest.AssertExpectations(t, client)
+}
+
+func TestSoftStartInhibit(t *testing.T) {
+	inhibit := make(chan bool)
+	_, client, _ := startWorker(t, discharger.WithSoftStart(time.Hour, 2), discharger.WithInhibitSignal(inhibit))
+	client.ExpectStartDischarge(1)
+
+	testutil.WaitFor(t, "first soft start step", func() bool {
+		return client.Power() == testPower/2
+	})
+	inhibit <- true
+	testutil.WaitFor(t, "discharge stop during the ramp", func() bool {
+		return client.Calls("StopDischarge") > 0
+	})
+	mocktest.AssertExpectations(t, client)
+}
+
+func TestSoftStopShutdown(t *testing.T) {
+	_, client, stop := startWorker(t, discharger.WithSoftStop(30*time.Minute, 2))
+	client.ExpectStopDischarge(1)
+
+	testutil.WaitFor(t, "discharge start", func() bool {
+		return client.Power() == testPower
+	})
+	// the ramp down would take half an hour; shutting down stops at once
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(testutil.WaitTimeout):
+		t.Fatal("timeout waiting for the worker to stop")
+	}
+	mocktest.AssertExpectations(t, client)
+}
@@ -0,0 +1,65 @@
+package discharger
+
+import (
+	"gok-pi/metrics/observers"
+	"sync"
+)
+
+// ExportStore accumulates the energy exported to the grid during a discharge session.
+type ExportStore interface {
+	Add(wh float64)
+	Total() float64
+	Reset()
+}
+
+// MemoryExportStore is an ExportStore that keeps the session total in memory.
+type MemoryExportStore struct {
+	total float64
+	mutex sync.Mutex
+}
+
+func NewMemoryExportStore() *MemoryExportStore {
+	return &MemoryExportStore{}
+}
+
+func (s *MemoryExportStore) Add(wh float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.total += wh
+}
+
+func (s *MemoryExportStore) Total() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.total
+}
+
+func (s *MemoryExportStore) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.total = 0
+}
+
+// SessionExportWh returns the energy exported to the grid during the current or last session,
+// or zero if grid export tracking is not enabled.
+func (d *Discharge) SessionExportWh() float64 {
+	if d.exportStore == nil {
+		return 0
+	}
+	return d.exportStore.Total()
+}
+
+// trackExport adds the part of the battery output not consumed by the house to the export store.
+// Only the interval since the previous status check is counted, and only while discharging.
+func (d *Discharge) trackExport() {
+	if d.exportStore == nil || d.status == nil || !d.isDischarging {
+		return
+	}
+	if d.status.PacTotalW <= 0 {
+		return
+	}
+	exportW := max(0, d.status.PacTotalW-d.status.ConsumptionW)
+	wh := exportW * checkInterval.Hours()
+	d.exportStore.Add(wh)
+	observers.AddGridExport(d.name, wh)
+}
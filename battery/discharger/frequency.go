@@ -0,0 +1,197 @@
+package discharger
+
+import (
+	"context"
+	"fmt"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/metrics/observers"
+	"log/slog"
+	"time"
+)
+
+const (
+	frequencyCheckInterval = 100 * time.Millisecond
+	// frequencyResponseTime is the time within which the battery has to follow a frequency deviation
+	frequencyResponseTime = 200 * time.Millisecond
+	// frequencyHysteresis keeps discharge running until the frequency recovers slightly above the threshold
+	frequencyHysteresis = 0.02
+)
+
+// FrequencySource provides the current grid frequency in Hz.
+type FrequencySource interface {
+	Frequency() (float64, error)
+}
+
+// setpointResult is the outcome of a discharge setpoint sent by frequency response.
+type setpointResult struct {
+	power   int
+	elapsed time.Duration
+	err     error
+}
+
+// RunFrequencyResponse discharges the battery while the grid frequency is below the threshold,
+// as required by frequency-response services (typically 49.9 Hz). The frequency is checked
+// every 100 ms.
+//
+// The battery is switched to manual mode once, on start, so a deviation only needs one setpoint
+// request: safety checks, hooks and the soft start and stop ramps are skipped, and no sessions are
+// recorded. Setpoints are sent in the background, one at a time, so neither a slow setpoint nor the
+// status refresh at the regular interval delays the frequency checks; a setpoint taking longer than
+// frequencyResponseTime is logged. Limits are still respected.
+// It blocks until the context is cancelled and must not run concurrently with Run.
+func (d *Discharge) RunFrequencyResponse(ctx context.Context, freqSource FrequencySource, threshold float64) error {
+	status, err := d.client.Status()
+	if err != nil {
+		return fmt.Errorf("checking battery status: %w", err)
+	}
+	d.status = status
+	err = d.setOperatingMode(entity.Manual)
+	if err != nil {
+		return fmt.Errorf("switching operating mode: %w", err)
+	}
+	d.log.With(slog.Float64("threshold", threshold)).Info("starting frequency response")
+
+	frequencyTicker := time.NewTicker(frequencyCheckInterval)
+	defer frequencyTicker.Stop()
+	statuses := make(chan *entity.SystemStatus)
+	go d.refreshStatus(ctx, statuses)
+	results := make(chan setpointResult, 1)
+	pending := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			if pending {
+				d.applySetpoint(<-results)
+			}
+			d.stopFrequencyResponse()
+			d.log.Info("frequency response stopped")
+			return nil
+		case status = <-statuses:
+			d.status = status
+			d.observeStatus()
+			d.trackExport()
+			d.saveSnapshot()
+		case result := <-results:
+			pending = false
+			d.applySetpoint(result)
+		case <-frequencyTicker.C:
+			if pending {
+				continue
+			}
+			frequency, err := freqSource.Frequency()
+			if err != nil {
+				d.log.With(sl.Err(err)).Error("reading grid frequency")
+				continue
+			}
+			power := d.frequencySetpoint(frequency, threshold)
+			if power == d.setpoint() {
+				continue
+			}
+			pending = true
+			go func() {
+				start := time.Now()
+				err := d.sendSetpoint(power)
+				results <- setpointResult{power: power, elapsed: time.Since(start), err: err}
+			}()
+		}
+		d.publishState()
+	}
+}
+
+// refreshStatus reads the battery status at the regular interval and passes it to the frequency response
+// loop, until the context is cancelled.
+func (d *Discharge) refreshStatus(ctx context.Context, statuses chan<- *entity.SystemStatus) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		status, err := d.client.Status()
+		if err != nil {
+			d.log.With(sl.Err(err)).Error("checking battery status")
+			observers.AddClientError(d.name, "status")
+			continue
+		}
+		select {
+		case statuses <- status:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// frequencySetpoint returns the discharge power for the grid frequency: the power limit while the frequency
+// is below the threshold and the battery is above its limits, zero otherwise.
+func (d *Discharge) frequencySetpoint(frequency, threshold float64) int {
+	log := d.log.With(slog.Float64("frequency", frequency))
+	switch {
+	case frequency < threshold && !d.isDischarging:
+		if !d.isReadyToDischarge() {
+			return 0
+		}
+		log.Info("grid frequency below threshold")
+		power := d.powerLimit
+		if d.spec != nil && d.spec.MaxDischargeRateW > 0 && float64(power) > d.spec.MaxDischargeRateW {
+			power = int(d.spec.MaxDischargeRateW)
+		}
+		return power
+	case d.isDischarging && !d.isReadyToDischarge():
+		log.Info("battery level reached the limit, stopping frequency response")
+		return 0
+	case d.isDischarging && frequency >= threshold+frequencyHysteresis:
+		log.Info("grid frequency recovered")
+		return 0
+	}
+	return d.setpoint()
+}
+
+// setpoint returns the discharge power currently applied.
+func (d *Discharge) setpoint() int {
+	if !d.isDischarging {
+		return 0
+	}
+	return d.power
+}
+
+// sendSetpoint sets the discharge power directly, without changing the operating mode.
+func (d *Discharge) sendSetpoint(power int) error {
+	if power == 0 {
+		return d.client.StopDischarge()
+	}
+	return d.client.StartDischarge(power)
+}
+
+// applySetpoint records a setpoint once the battery accepted it.
+func (d *Discharge) applySetpoint(result setpointResult) {
+	log := d.log.With(
+		slog.Int("power", result.power),
+		slog.Duration("elapsed", result.elapsed),
+	)
+	if result.err != nil {
+		log.With(sl.Err(result.err)).Error("setting frequency response setpoint")
+		observers.AddClientError(d.name, "frequency_setpoint")
+		return
+	}
+	if result.elapsed > frequencyResponseTime {
+		log.Warn("frequency response setpoint exceeded the response time")
+	}
+	d.isDischarging = result.power > 0
+	d.power = result.power
+}
+
+// stopFrequencyResponse stops discharge and returns the battery to automatic mode.
+func (d *Discharge) stopFrequencyResponse() {
+	if d.isDischarging {
+		start := time.Now()
+		d.applySetpoint(setpointResult{err: d.sendSetpoint(0), elapsed: time.Since(start)})
+	}
+	if err := d.client.SetOperatingMode(entity.Automatic); err != nil {
+		d.log.With(sl.Err(err)).Error("switching operating mode")
+		observers.AddClientError(d.name, "set_operating_mode")
+	}
+}
@@ -0,0 +1,48 @@
+package discharger
+
+import (
+	"context"
+	"log/slog"
+	"math"
+)
+
+// EventType groups the log entries of a discharge worker, so each group can be logged at its own level.
+type EventType string
+
+const (
+	EventStatus       EventType = "status"
+	EventSessionStart EventType = "session_start"
+	EventSessionStop  EventType = "session_stop"
+	EventSchedule     EventType = "schedule"
+	EventControl      EventType = "control"
+	EventDailySummary EventType = "daily_summary"
+)
+
+// logEvent writes msg at the level configured for the event type, INFO by default.
+func (d *Discharge) logEvent(log *slog.Logger, event EventType, msg string) {
+	level, ok := d.logLevels[event]
+	if !ok {
+		level = slog.LevelInfo
+	}
+	log.Log(context.Background(), level, msg)
+}
+
+// logStatus logs the battery status when the SoC moved by more than the significant change threshold
+// since the last logged value. A forced entry, at session start and end, is written regardless.
+// Status logging is off unless a threshold is set with WithSignificantChangeThreshold.
+func (d *Discharge) logStatus(force bool) {
+	if d.statusThreshold <= 0 || d.status == nil {
+		return
+	}
+	if !force && d.statusLogged && math.Abs(d.status.RSOC-d.loggedSoC) <= d.statusThreshold {
+		return
+	}
+	d.statusLogged = true
+	d.loggedSoC = d.status.RSOC
+	d.logEvent(d.log.With(
+		slog.Float64("SoC", d.status.RSOC),
+		slog.Float64("pac", d.status.PacTotalW),
+		slog.Float64("consumption", d.status.ConsumptionW),
+		slog.Bool("discharging", d.isDischarging),
+	), EventStatus, "battery status")
+}
@@ -0,0 +1,205 @@
+package discharger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/metrics/observers"
+	"log/slog"
+	"time"
+)
+
+const hookTimeout = 5 * time.Second
+
+// DischargeHook is called around every discharge session. An error from BeforeStart cancels the start;
+// errors from AfterStop are logged, since the session is already over.
+type DischargeHook interface {
+	BeforeStart(ctx context.Context, status *entity.SystemStatus) error
+	AfterStop(ctx context.Context, session entity.SessionSummary) error
+}
+
+// Notifier delivers a short message to the user, e.g. by push notification or chat message.
+type Notifier interface {
+	Notify(ctx context.Context, subject, message string) error
+}
+
+// beforeStart calls the BeforeStart hooks in order and stops at the first error.
+func (d *Discharge) beforeStart() error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	for _, hook := range d.hooks {
+		if err := hook.BeforeStart(ctx, d.status); err != nil {
+			return fmt.Errorf("before start hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// afterStop calls the AfterStop hooks in order with the completed session.
+func (d *Discharge) afterStop(session entity.SessionSummary) {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	for _, hook := range d.hooks {
+		if err := hook.AfterStop(ctx, session); err != nil {
+			d.log.With(sl.Err(err)).Error("after stop hook")
+		}
+	}
+}
+
+// MetricsHook counts completed sessions and their delivered energy and avoided CO2 in Prometheus.
+// It is installed by default.
+type MetricsHook struct{}
+
+func (MetricsHook) BeforeStart(_ context.Context, _ *entity.SystemStatus) error {
+	return nil
+}
+
+func (MetricsHook) AfterStop(_ context.Context, session entity.SessionSummary) error {
+	observers.AddSession(session.Battery, session.StopReason, session.EnergyWh)
+	observers.AddCO2Saved(session.Battery, session.CO2SavedKg)
+	return nil
+}
+
+// NotificationHook notifies the user when a session starts and when it ends.
+type NotificationHook struct {
+	name     string
+	notifier Notifier
+}
+
+func NewNotificationHook(name string, notifier Notifier) *NotificationHook {
+	return &NotificationHook{
+		name:     name,
+		notifier: notifier,
+	}
+}
+
+func (h *NotificationHook) BeforeStart(ctx context.Context, status *entity.SystemStatus) error {
+	message := "discharge starting"
+	if status != nil {
+		message = fmt.Sprintf("discharge starting at %.0f%% SoC", status.RSOC)
+	}
+	// a failed notification must not keep the battery from discharging
+	_ = h.notifier.Notify(ctx, h.name, message)
+	return nil
+}
+
+func (h *NotificationHook) AfterStop(ctx context.Context, session entity.SessionSummary) error {
+	return h.notifier.Notify(ctx, h.name, fmt.Sprintf("discharge stopped (%s) at %.0f%% SoC, %.0f Wh delivered",
+		session.StopReason, session.EndSoC, session.EnergyWh))
+}
+
+// AuditHook writes the start and the end of every session to the audit log.
+type AuditHook struct {
+	log *slog.Logger
+}
+
+func NewAuditHook(log *slog.Logger) *AuditHook {
+	return &AuditHook{
+		log: log.With(slog.String("audit", "discharge")),
+	}
+}
+
+func (h *AuditHook) BeforeStart(_ context.Context, status *entity.SystemStatus) error {
+	log := h.log
+	if status != nil {
+		log = log.With(slog.Float64("SoC", status.RSOC))
+	}
+	log.Info("discharge session starting")
+	return nil
+}
+
+func (h *AuditHook) AfterStop(_ context.Context, session entity.SessionSummary) error {
+	h.log.With(
+		slog.String("battery", session.Battery),
+		slog.Time("start", session.StartTime),
+		slog.Time("stop", session.StopTime),
+		slog.Float64("energy_wh", session.EnergyWh),
+		slog.String("stop_reason", session.StopReason),
+	).Info("discharge session completed")
+	return nil
+}
+
+// SafetyCheck is a pre-flight check run before every session start. When a check fails, the start is
+// skipped rather than cancelled: it is tried again on the next status check.
+type SafetyCheck interface {
+	Check(ctx context.Context, status *entity.SystemStatus) error
+}
+
+// SafetyCheckFunc adapts a function to the SafetyCheck interface.
+type SafetyCheckFunc func(ctx context.Context, status *entity.SystemStatus) error
+
+func (f SafetyCheckFunc) Check(ctx context.Context, status *entity.SystemStatus) error {
+	return f(ctx, status)
+}
+
+// DischargeInhibited is the event published in the worker state while a safety check or an inhibit signal
+// keeps a session from starting.
+type DischargeInhibited struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+
+// MinSoC requires the state of charge to be above pct.
+func MinSoC(pct float64) SafetyCheck {
+	return SafetyCheckFunc(func(_ context.Context, status *entity.SystemStatus) error {
+		if status.RSOC <= pct {
+			return fmt.Errorf("SoC %.1f%% not above %.1f%%", status.RSOC, pct)
+		}
+		return nil
+	})
+}
+
+// DischargeAllowed requires that the BMS does not block discharge, e.g. because of an active alarm.
+func DischargeAllowed() SafetyCheck {
+	return SafetyCheckFunc(func(_ context.Context, status *entity.SystemStatus) error {
+		if status.DischargeNotAllowed {
+			return errors.New("discharge not allowed by the BMS")
+		}
+		return nil
+	})
+}
+
+// ClientReachable requires a fresh reading, rather than the last known one of a reconnecting client.
+func ClientReachable() SafetyCheck {
+	return SafetyCheckFunc(func(_ context.Context, status *entity.SystemStatus) error {
+		if status.Stale {
+			return errors.New("battery client not reachable")
+		}
+		return nil
+	})
+}
+
+// GridAvailable requires the inverter to be connected to the grid, as told by the detector.
+func GridAvailable(detector IslandDetector) SafetyCheck {
+	return SafetyCheckFunc(func(_ context.Context, _ *entity.SystemStatus) error {
+		if detector.IsIslanded() {
+			return errors.New("grid not available")
+		}
+		return nil
+	})
+}
+
+// checkSafety runs the safety checks and tells whether a session may start. A failed check is logged
+// when its reason changes, so a start inhibited for the whole window does not flood the log.
+func (d *Discharge) checkSafety() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	for _, check := range d.safetyChecks {
+		err := check.Check(ctx, d.status)
+		if err == nil {
+			continue
+		}
+		if d.inhibited == nil || d.inhibited.Reason != err.Error() {
+			d.log.With(sl.Err(err)).Warn("discharge inhibited by safety check")
+			d.inhibited = &DischargeInhibited{
+				Time:   time.Now(),
+				Reason: err.Error(),
+			}
+		}
+		return false
+	}
+	d.inhibited = nil
+	return true
+}
@@ -0,0 +1,238 @@
+package discharger
+
+import (
+	"context"
+	"gok-pi/battery/entity"
+	"gok-pi/battery/scheduler"
+	"gok-pi/battery/storage"
+	"gok-pi/battery/tariff"
+	"log/slog"
+	"time"
+)
+
+type Option func(*Discharge)
+
+// WithGridExportTracking enables accounting of the energy exported to the grid
+// while a discharge session is active, accumulated in the given store.
+func WithGridExportTracking(store ExportStore) Option {
+	return func(d *Discharge) {
+		d.exportStore = store
+	}
+}
+
+// WithTariffSource sets the source of import prices used to estimate the cost saved by each session.
+func WithTariffSource(source tariff.TariffSource) Option {
+	return func(d *Discharge) {
+		d.tariffSource = source
+	}
+}
+
+// WithCarbonSource sets the source of grid carbon intensity used to estimate the CO2 avoided by each session.
+func WithCarbonSource(source CarbonSource) Option {
+	return func(d *Discharge) {
+		d.carbonSource = source
+	}
+}
+
+// WithEventStore enables recording of status snapshots and completed sessions to the store.
+func WithEventStore(store storage.EventStore) Option {
+	return func(d *Discharge) {
+		d.eventStore = store
+	}
+}
+
+// WithMaxSessionEnergyWh stops a session once it has delivered the given energy, even if the SoC limit
+// is not reached yet. The battery is not discharged again until the next scheduled window.
+func WithMaxSessionEnergyWh(wh float64) Option {
+	return func(d *Discharge) {
+		d.maxEnergyWh = wh
+	}
+}
+
+// WithStartJitter delays every scheduled start by a random duration in [0, maxJitter].
+func WithStartJitter(maxJitter time.Duration) Option {
+	return func(d *Discharge) {
+		d.maxJitter = maxJitter
+	}
+}
+
+// WithStopConditions adds conditions that stop an active discharge, evaluated in the given order.
+func WithStopConditions(conditions ...StopCondition) Option {
+	return func(d *Discharge) {
+		d.stopConditions = append(d.stopConditions, conditions...)
+	}
+}
+
+// WithMetricsObserver replaces the observer of status readings, which by default updates
+// the Prometheus gauges of the observers package; nil disables status metrics.
+func WithMetricsObserver(observer MetricsObserver) Option {
+	return func(d *Discharge) {
+		d.metrics = observer
+	}
+}
+
+// WithEarlyStartThreshold starts discharge up to two hours ahead of the scheduled start
+// if the SoC is at or above the given percentage.
+func WithEarlyStartThreshold(soc float64) Option {
+	return func(d *Discharge) {
+		d.earlyStartSoC = soc
+	}
+}
+
+// WithSoftStart raises the discharge power from zero to the target in the given number of steps
+// spread over rampDuration, to avoid a voltage step on the local grid.
+func WithSoftStart(rampDuration time.Duration, steps int) Option {
+	return func(d *Discharge) {
+		d.softStart = ramp{duration: rampDuration, steps: steps}
+	}
+}
+
+// WithSoftStop lowers the discharge power to zero in the given number of steps spread over rampDuration
+// before stopping. Scheduled sessions begin the ramp rampDuration before the stop time. A forced stop,
+// an inhibit signal, an islanded grid or shutdown skip the rest of the ramp and stop at once.
+func WithSoftStop(rampDuration time.Duration, steps int) Option {
+	return func(d *Discharge) {
+		d.softStop = ramp{duration: rampDuration, steps: steps}
+	}
+}
+
+// WithReserveSoC sets the minimum SoC the battery must hold to be available for spinning reserve.
+func WithReserveSoC(soc float64) Option {
+	return func(d *Discharge) {
+		d.reserveSoC = soc
+	}
+}
+
+// WithDispatchDuration sets how long the reserve power is delivered after a dispatch request.
+func WithDispatchDuration(duration time.Duration) Option {
+	return func(d *Discharge) {
+		d.dispatchTime = duration
+	}
+}
+
+// WithIslandDetector inhibits discharge while the detector reports that the grid is disconnected.
+func WithIslandDetector(detector IslandDetector) Option {
+	return func(d *Discharge) {
+		d.islandDetect = detector
+	}
+}
+
+// WithBatterySpec sets the static battery specification; its maximum discharge rate caps the discharge power.
+func WithBatterySpec(spec entity.BatterySpec) Option {
+	return func(d *Discharge) {
+		d.spec = &spec
+	}
+}
+
+// WithHooks adds hooks called before and after every discharge session, in the given order.
+func WithHooks(hooks ...DischargeHook) Option {
+	return func(d *Discharge) {
+		d.hooks = append(d.hooks, hooks...)
+	}
+}
+
+// WithSafetyChecks adds checks that must all pass before a session starts, evaluated in the given order.
+func WithSafetyChecks(checks ...SafetyCheck) Option {
+	return func(d *Discharge) {
+		d.safetyChecks = append(d.safetyChecks, checks...)
+	}
+}
+
+// WithMidWindowStart sets whether a worker started within the discharge window starts discharging
+// right away (the default) or waits for the next window.
+func WithMidWindowStart(enabled bool) Option {
+	return func(d *Discharge) {
+		d.midWindowStart = enabled
+	}
+}
+
+// WithSchedulePersistence saves the next discharge window to the store whenever it changes. After a restart,
+// a saved window that has not started yet is used instead of computing a new one.
+func WithSchedulePersistence(store ScheduleStore) Option {
+	return func(d *Discharge) {
+		d.scheduleStore = store
+	}
+}
+
+// WithInhibitSignal lets an external system, e.g. a smart home during EV charging, suppress discharge:
+// true stops an active discharge and prevents new sessions, false resumes normal scheduling.
+func WithInhibitSignal(signal <-chan bool) Option {
+	return func(d *Discharge) {
+		d.inhibitSignal = signal
+	}
+}
+
+// WithStartTrigger lets an external system request discharge outside the schedule. One request is
+// honoured at a time; later requests are queued, and dropped with a warning when the queue is full.
+func WithStartTrigger(trigger <-chan DischargeRequest) Option {
+	return func(d *Discharge) {
+		d.startTrigger = trigger
+	}
+}
+
+// WithSignificantChangeThreshold logs the battery status at INFO level when the SoC changed by more than
+// pct percentage points since the last logged value, and at the start and end of every session.
+func WithSignificantChangeThreshold(pct float64) Option {
+	return func(d *Discharge) {
+		d.statusThreshold = pct
+	}
+}
+
+// WithLogLevels sets the log level per event type, e.g. status ticks at DEBUG while session start
+// and stop stay at INFO. Event types not in the map are logged at INFO.
+func WithLogLevels(levels map[EventType]slog.Level) Option {
+	return func(d *Discharge) {
+		d.logLevels = levels
+	}
+}
+
+// WithHolidayCalendar skips scheduled sessions that start on a holiday of the calendar, as tariffs
+// often differ on public holidays. A forced start is not affected.
+func WithHolidayCalendar(cal HolidayCalendar) Option {
+	return func(d *Discharge) {
+		d.holidays = cal
+	}
+}
+
+// WithDynamicLimit computes the SoC limit for each discharge window from its start time, e.g. with
+// scheduler.SeasonalLimitFn; it replaces the limit set with SetLimits.
+func WithDynamicLimit(fn func(time.Time) float64) Option {
+	return func(d *Discharge) {
+		d.dynamicLimit = fn
+	}
+}
+
+// WithScheduler takes the discharge windows from the scheduler, e.g. filled from an iCal file or a remote
+// schedule, instead of the daily window set with SetTime. A window with a SoC limit replaces the limit
+// for its session.
+func WithScheduler(s *scheduler.Scheduler) Option {
+	return func(d *Discharge) {
+		d.schedule = s
+	}
+}
+
+// WithDeadlockShutdown calls cancel, e.g. of the daemon's context, when the worker loop is detected to be
+// stuck, see detectDeadlock. A worker stuck on a client call cannot recover by itself, so the daemon exits
+// and its supervisor restarts it.
+func WithDeadlockShutdown(cancel context.CancelFunc) Option {
+	return func(d *Discharge) {
+		d.onDeadlock = cancel
+	}
+}
+
+// WithBelowLimitBehaviour sets what happens when the SoC is already at or below the limit at the start
+// of a scheduled window: skip the window (the default), discharge for the full window anyway, or charge
+// the battery, see WithChargeTarget.
+func WithBelowLimitBehaviour(b BelowLimitBehaviour) Option {
+	return func(d *Discharge) {
+		d.belowLimit = b
+	}
+}
+
+// WithChargeTarget sets the SoC that BelowLimitCharge charges the battery to; by default, it charges
+// back to the SoC limit.
+func WithChargeTarget(soc float64) Option {
+	return func(d *Discharge) {
+		d.chargeTarget = soc
+	}
+}
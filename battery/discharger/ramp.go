@@ -0,0 +1,108 @@
+package discharger
+
+import (
+	"log/slog"
+	"time"
+)
+
+// ramp changes the discharge power between two values in equal steps, with rampDuration/steps between them.
+// The worker loop waits for the ramp, but a forced stop, an inhibit signal, an islanded grid or the end of
+// Run cut it short; other commands and signals received meanwhile are handled once the ramp returns.
+type ramp struct {
+	duration time.Duration
+	steps    int
+}
+
+func (r ramp) enabled() bool {
+	return r.duration > 0 && r.steps > 1
+}
+
+// rampUp starts discharge with the given power, reaching it gradually if soft start is configured.
+// It returns the power reached, which is lower than requested if the ramp was cut short.
+func (d *Discharge) rampUp(power int) (int, error) {
+	if !d.softStart.enabled() {
+		return power, d.client.StartDischarge(power)
+	}
+	d.log.With(
+		slog.Int("power", power),
+		slog.Duration("ramp", d.softStart.duration),
+	).Debug("soft start")
+	delay := d.softStart.duration / time.Duration(d.softStart.steps)
+	reached := 0
+	for i := 1; i <= d.softStart.steps; i++ {
+		if i > 1 && !d.rampWait(delay) {
+			d.log.With(slog.Int("power", reached)).Debug("soft start cut short")
+			return reached, nil
+		}
+		err := d.client.StartDischarge(power * i / d.softStart.steps)
+		if err != nil {
+			return reached, err
+		}
+		reached = power * i / d.softStart.steps
+	}
+	return reached, nil
+}
+
+// rampDown stops discharge, lowering the power gradually first if soft stop is configured.
+// A failed or interrupted intermediate step does not prevent the stop command.
+func (d *Discharge) rampDown() error {
+	if d.softStop.enabled() && d.power > 0 {
+		d.log.With(
+			slog.Int("power", d.power),
+			slog.Duration("ramp", d.softStop.duration),
+		).Debug("soft stop")
+		delay := d.softStop.duration / time.Duration(d.softStop.steps)
+		for i := d.softStop.steps - 1; i > 0; i-- {
+			err := d.client.StartDischarge(d.power * i / d.softStop.steps)
+			if err != nil {
+				d.log.Warn("soft stop step failed, stopping at once")
+				break
+			}
+			if !d.rampWait(delay) {
+				d.log.Debug("soft stop cut short, stopping at once")
+				break
+			}
+		}
+	}
+	return d.client.StopDischarge()
+}
+
+// rampWait waits for the delay between two ramp steps and reports whether the ramp may continue.
+// Commands and inhibit signals received meanwhile are deferred to the worker loop; a forced stop or
+// an inhibit ends the ramp, as do the end of Run and an islanded grid.
+func (d *Discharge) rampWait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			d.heartbeat()
+			return d.islandDetect == nil || !d.islandDetect.IsIslanded()
+		case <-d.runDone:
+			return false
+		case cmd := <-d.commands:
+			d.deferred = append(d.deferred, func() { d.handleCommand(cmd) })
+			if cmd == commandForceStop {
+				return false
+			}
+		case inhibit, ok := <-d.inhibitSignal:
+			if !ok {
+				d.inhibitSignal = nil
+				continue
+			}
+			d.deferred = append(d.deferred, func() { d.handleInhibit(inhibit) })
+			if inhibit {
+				return false
+			}
+		}
+	}
+}
+
+// runDeferred handles the commands and signals received during a ramp, in the order they arrived.
+func (d *Discharge) runDeferred() {
+	for len(d.deferred) > 0 {
+		fn := d.deferred[0]
+		d.deferred = d.deferred[1:]
+		fn()
+	}
+}
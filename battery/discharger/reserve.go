@@ -0,0 +1,104 @@
+package discharger
+
+import (
+	"context"
+	"fmt"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/metrics/observers"
+	"log/slog"
+	"time"
+)
+
+const defaultDispatchTime = 15 * time.Minute
+
+// RunSpinningReserve keeps the battery available as synchronous reserve and delivers reserveWatts
+// as soon as a request arrives on trigger, for the configured dispatch duration.
+// Between dispatches the battery stays in automatic mode so it charges up to the reserve SoC on its own;
+// requests arriving while the SoC is below that level are rejected and logged.
+// It blocks until the context is cancelled and must not run concurrently with Run.
+func (d *Discharge) RunSpinningReserve(ctx context.Context, reserveWatts float64, trigger <-chan struct{}) error {
+	status, err := d.client.Status()
+	if err != nil {
+		return fmt.Errorf("checking battery status: %w", err)
+	}
+	d.status = status
+	log := d.log.With(
+		slog.Float64("reserve_w", reserveWatts),
+		slog.Float64("reserve_soc", d.reserveSoC),
+		slog.Duration("dispatch_time", d.dispatchTime),
+	)
+	log.Info("starting spinning reserve")
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	var dispatch *time.Timer
+	var dispatchEnd <-chan time.Time
+	ready := d.isReserveReady()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if dispatch != nil {
+				dispatch.Stop()
+			}
+			d.stopReserve()
+			log.Info("spinning reserve stopped")
+			return nil
+		case <-ticker.C:
+			status, err = d.client.Status()
+			if err != nil {
+				d.log.With(sl.Err(err)).Error("checking battery status")
+				observers.AddClientError(d.name, "status")
+				continue
+			}
+			d.status = status
+			d.observeStatus()
+			d.trackExport()
+			d.trackSession()
+			d.saveSnapshot()
+
+			if d.isDischarging && !d.isReadyToDischarge() {
+				log.Info("battery level reached the limit, stopping dispatch")
+				d.stopReserve()
+			}
+			if isReady := d.isReserveReady(); isReady != ready {
+				ready = isReady
+				log.With(slog.Bool("ready", ready), slog.Float64("SoC", status.RSOC)).Info("reserve availability changed")
+			}
+		case <-trigger:
+			if d.isDischarging {
+				log.Warn("dispatch already in progress")
+				continue
+			}
+			if !d.isReserveReady() {
+				log.With(slog.Float64("SoC", d.status.RSOC)).Warn("reserve not available, dispatch rejected")
+				continue
+			}
+			log.Info("dispatching reserve")
+			if err = d.startDischarge(int(reserveWatts)); err != nil {
+				d.log.With(sl.Err(err)).Error("starting discharge")
+				observers.AddClientError(d.name, "start_discharge")
+				continue
+			}
+			dispatch = time.NewTimer(d.dispatchTime)
+			dispatchEnd = dispatch.C
+		case <-dispatchEnd:
+			dispatch, dispatchEnd = nil, nil
+			log.Info("dispatch completed")
+			d.stopReserve()
+		}
+		d.publishState()
+	}
+}
+
+func (d *Discharge) isReserveReady() bool {
+	return d.isReadyToDischarge() && d.status.RSOC >= d.reserveSoC
+}
+
+func (d *Discharge) stopReserve() {
+	if err := d.stopDischarge(); err != nil {
+		d.log.With(sl.Err(err)).Error("stopping discharge")
+		observers.AddClientError(d.name, "stop_discharge")
+	}
+}
@@ -0,0 +1,125 @@
+package discharger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/internal/lib/timer"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// ScheduleStore keeps the next discharge window across restarts.
+type ScheduleStore interface {
+	Save(window timer.TimeRange) error
+	// Load returns the saved window; ok is false if none was saved yet.
+	Load() (window timer.TimeRange, ok bool, err error)
+}
+
+// MemoryScheduleStore is a ScheduleStore that keeps the window in memory, e.g. for a worker restarted in process.
+type MemoryScheduleStore struct {
+	window *timer.TimeRange
+	mutex  sync.Mutex
+}
+
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{}
+}
+
+func (s *MemoryScheduleStore) Save(window timer.TimeRange) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.window = &window
+	return nil
+}
+
+func (s *MemoryScheduleStore) Load() (timer.TimeRange, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.window == nil {
+		return timer.TimeRange{}, false, nil
+	}
+	return *s.window, true, nil
+}
+
+// FileScheduleStore is a ScheduleStore that keeps the window in a JSON file.
+type FileScheduleStore struct {
+	path string
+}
+
+type savedSchedule struct {
+	NextStart time.Time `json:"next_start"`
+	NextStop  time.Time `json:"next_stop"`
+}
+
+func NewFileScheduleStore(path string) *FileScheduleStore {
+	return &FileScheduleStore{path: path}
+}
+
+// Save writes the window to a temporary file first, so a crash never leaves a partial file behind.
+func (s *FileScheduleStore) Save(window timer.TimeRange) error {
+	data, err := json.Marshal(savedSchedule{NextStart: window.Start, NextStop: window.Stop})
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing schedule: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *FileScheduleStore) Load() (timer.TimeRange, bool, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return timer.TimeRange{}, false, nil
+	}
+	if err != nil {
+		return timer.TimeRange{}, false, fmt.Errorf("reading schedule: %w", err)
+	}
+	var saved savedSchedule
+	if err = json.Unmarshal(data, &saved); err != nil {
+		return timer.TimeRange{}, false, fmt.Errorf("parsing schedule: %w", err)
+	}
+	return timer.TimeRange{Start: saved.NextStart, Stop: saved.NextStop}, true, nil
+}
+
+// restoreSchedule loads the window saved before a restart; it is used instead of the computed one
+// if it has not started yet, so a jittered start time is kept.
+func (d *Discharge) restoreSchedule() {
+	if d.scheduleStore == nil {
+		return
+	}
+	window, ok, err := d.scheduleStore.Load()
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("loading saved schedule")
+		return
+	}
+	if !ok || !window.Start.After(time.Now()) {
+		return
+	}
+	d.logEvent(d.log.With(
+		slog.Time("start", window.Start),
+		slog.Time("stop", window.Stop),
+	), EventSchedule, "restored saved schedule")
+	d.savedWindow = &window
+	d.persistedWindow = window
+}
+
+// persistSchedule saves a computed window when it differs from the one saved last.
+func (d *Discharge) persistSchedule(window timer.TimeRange) {
+	if d.scheduleStore == nil {
+		return
+	}
+	if window.Start.Equal(d.persistedWindow.Start) && window.Stop.Equal(d.persistedWindow.Stop) {
+		return
+	}
+	if err := d.scheduleStore.Save(window); err != nil {
+		d.log.With(sl.Err(err)).Error("saving schedule")
+		return
+	}
+	d.persistedWindow = window
+}
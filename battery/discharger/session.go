@@ -0,0 +1,145 @@
+package discharger
+
+import (
+	"context"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"time"
+)
+
+const (
+	storeTimeout = 5 * time.Second
+	// recentSnapshots is the number of snapshots kept in memory, one hour of status checks
+	recentSnapshots = int(time.Hour / checkInterval)
+)
+
+// LastSession returns a copy of the last completed discharge session, or nil if there is none yet.
+func (d *Discharge) LastSession() *entity.SessionSummary {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.lastSession == nil {
+		return nil
+	}
+	session := *d.lastSession
+	return &session
+}
+
+// startSession opens a new session record; the peak price is taken from the tariff source at start.
+func (d *Discharge) startSession() {
+	now := time.Now()
+	d.session = &entity.SessionSummary{
+		Battery:   d.name,
+		StartTime: now,
+		SocLimit:  d.socLimit,
+	}
+	if d.status != nil {
+		d.session.StartSoC = d.status.RSOC
+	}
+	d.logStatus(true)
+	if d.tariffSource != nil {
+		price, err := d.tariffSource.PriceAt(now)
+		if err != nil {
+			d.log.With(sl.Err(err)).Warn("getting tariff price")
+		} else {
+			d.session.PeakPrice = price
+		}
+	}
+	if d.carbonSource != nil {
+		intensity, err := d.carbonSource.IntensityAt(now)
+		if err != nil {
+			d.log.With(sl.Err(err)).Warn("getting carbon intensity")
+		} else {
+			d.session.CarbonIntensity = intensity
+		}
+	}
+}
+
+// trackSession adds the energy delivered by the battery since the previous status check.
+func (d *Discharge) trackSession() {
+	if d.session == nil || d.status == nil || d.status.PacTotalW <= 0 {
+		return
+	}
+	d.session.EnergyWh += d.status.PacTotalW * checkInterval.Hours()
+}
+
+// isSessionEnergyReached checks the energy delivered by the active session against the configured maximum.
+func (d *Discharge) isSessionEnergyReached() bool {
+	return d.maxEnergyWh > 0 && d.session != nil && d.session.EnergyWh >= d.maxEnergyWh
+}
+
+// closeSession completes the active session and logs its summary.
+func (d *Discharge) closeSession() {
+	if d.session == nil {
+		return
+	}
+	session := d.session
+	d.session = nil
+
+	session.StopTime = time.Now()
+	session.StopReason = d.stopReason
+	d.stopReason = ""
+	if d.status != nil {
+		session.EndSoC = d.status.RSOC
+	}
+	session.CostSaved = session.EnergyWh * session.PeakPrice / 1000
+	session.CO2SavedKg = session.EnergyWh / 1000 * session.CarbonIntensity / 1000
+
+	d.mutex.Lock()
+	d.lastSession = session
+	if d.dailyCostSaved == nil {
+		d.dailyCostSaved = make(map[string]float64)
+	}
+	d.dailyCostSaved[session.StartTime.Format(time.DateOnly)] += session.CostSaved
+	d.mutex.Unlock()
+
+	if d.eventStore != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		defer cancel()
+		if err := d.eventStore.SaveSession(ctx, *session); err != nil {
+			d.log.With(sl.Err(err)).Error("saving session")
+		}
+	}
+
+	d.logEvent(d.log.With(
+		slog.Time("start", session.StartTime),
+		slog.Duration("duration", session.StopTime.Sub(session.StartTime)),
+		slog.Float64("start_soc", session.StartSoC),
+		slog.Float64("end_soc", session.EndSoC),
+		slog.Float64("energy_wh", session.EnergyWh),
+		slog.Float64("peak_price", session.PeakPrice),
+		slog.Float64("cost_saved", session.CostSaved),
+		slog.Float64("co2_saved_kg", session.CO2SavedKg),
+		slog.String("stop_reason", session.StopReason),
+	), EventSessionStop, "discharge session summary")
+	d.logStatus(true)
+
+	d.afterStop(*session)
+}
+
+// RecentSnapshots returns the snapshots of the last hour held in memory, oldest first.
+func (d *Discharge) RecentSnapshots() []entity.BatterySnapshot {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.recent.All()
+}
+
+// saveSnapshot keeps the current status in memory and records it to the event store, if one is configured.
+func (d *Discharge) saveSnapshot() {
+	if d.status == nil {
+		return
+	}
+	snapshot := entity.NewBatterySnapshot(d.name, time.Now(), d.status)
+	d.mutex.Lock()
+	d.recent.Push(snapshot)
+	d.mutex.Unlock()
+	if d.eventStore == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	err := d.eventStore.SaveSnapshot(ctx, snapshot)
+	if err != nil {
+		d.log.With(sl.Err(err)).Error("saving snapshot")
+	}
+}
@@ -0,0 +1,78 @@
+package discharger
+
+import (
+	"context"
+	"fmt"
+	"gok-pi/battery/entity"
+	"log/slog"
+)
+
+// Stop reasons are recorded in the session summary and label the sessions counter;
+// "soc_limit" and "schedule" tell a session that reached its target from one cut off by the stop time.
+const (
+	stopReasonSchedule  = "schedule"
+	stopReasonSoC       = "soc_limit"
+	stopReasonEnergy    = "max_session_energy_reached"
+	stopReasonForced    = "forced_stop"
+	stopReasonIslanded  = "grid_islanded"
+	stopReasonShutdown  = "shutdown"
+	stopReasonExternal  = "external_stop"
+	stopReasonFrequency = "grid_frequency_low"
+	stopReasonSignal    = "external_stop_signal"
+	stopReasonInhibit   = "inhibit_signal"
+)
+
+// StopCondition decides whether an active discharge must stop. Conditions are evaluated on every
+// check while discharging, in the configured order; the first one that matches stops the session
+// and its reason is logged and recorded in the session summary.
+type StopCondition interface {
+	ShouldStop(ctx context.Context, status *entity.SystemStatus) (bool, string)
+}
+
+// StopConditionFunc adapts a function to the StopCondition interface.
+type StopConditionFunc func(ctx context.Context, status *entity.SystemStatus) (bool, string)
+
+func (f StopConditionFunc) ShouldStop(ctx context.Context, status *entity.SystemStatus) (bool, string) {
+	return f(ctx, status)
+}
+
+// FrequencyBelow stops discharge when the grid frequency reported by the inverter drops below hz.
+func FrequencyBelow(hz float64) StopCondition {
+	return StopConditionFunc(func(_ context.Context, status *entity.SystemStatus) (bool, string) {
+		if status.Fac > 0 && status.Fac < hz {
+			return true, fmt.Sprintf("%s: %.2f Hz", stopReasonFrequency, status.Fac)
+		}
+		return false, ""
+	})
+}
+
+// StopSignal stops discharge when a value is received on signal; each value stops one session.
+func StopSignal(signal <-chan struct{}) StopCondition {
+	return StopConditionFunc(func(_ context.Context, _ *entity.SystemStatus) (bool, string) {
+		select {
+		case <-signal:
+			return true, stopReasonSignal
+		default:
+			return false, ""
+		}
+	})
+}
+
+// checkStopConditions stops an active discharge if one of the stop conditions matches.
+// The battery is then not discharged again until the next scheduled window.
+func (d *Discharge) checkStopConditions(ctx context.Context) {
+	if !d.isDischarging || d.status == nil {
+		return
+	}
+	for _, condition := range d.stopConditions {
+		stop, reason := condition.ShouldStop(ctx, d.status)
+		if !stop {
+			continue
+		}
+		d.logEvent(d.log.With(slog.String("reason", reason)), EventSessionStop, "stop condition matched, stopping discharge")
+		d.holdUntilWindow = true
+		d.override = overrideNone
+		d.stopWithReason(reason)
+		return
+	}
+}
@@ -0,0 +1,69 @@
+package discharger
+
+import (
+	"log/slog"
+	"time"
+)
+
+// maxQueuedRequests is the number of external discharge requests waiting behind the active one.
+const maxQueuedRequests = 4
+
+const stopReasonRequest = "external_request_completed"
+
+// DischargeRequest asks for discharge outside the schedule until StopAt, or until the SoC drops to LimitPct.
+// The SoC limit of the worker still applies.
+type DischargeRequest struct {
+	StopAt   time.Time
+	LimitPct float64
+}
+
+// handleStartRequest activates an external discharge request, or queues it behind the active one.
+func (d *Discharge) handleStartRequest(request DischargeRequest) {
+	log := d.log.With(
+		slog.Time("stop_at", request.StopAt),
+		slog.Float64("limit_pct", request.LimitPct),
+	)
+	if d.request == nil {
+		d.logEvent(log, EventControl, "external discharge request received")
+		d.request = &request
+		return
+	}
+	if len(d.requestQueue) >= maxQueuedRequests {
+		log.Warn("external discharge request queue is full, request dropped")
+		return
+	}
+	d.logEvent(log, EventControl, "external discharge request queued")
+	d.requestQueue = append(d.requestQueue, request)
+}
+
+// externalRequestActive tells whether an external request asks for discharge now. Requests that have
+// reached their stop time or SoC limit are completed and replaced by the next queued one.
+func (d *Discharge) externalRequestActive() bool {
+	for d.request != nil {
+		if time.Now().Before(d.request.StopAt) && d.isReadyToDischarge() && d.status.RSOC > d.request.LimitPct {
+			return true
+		}
+		d.logEvent(d.log, EventControl, "external discharge request completed")
+		d.request = nil
+		if len(d.requestQueue) > 0 {
+			next := d.requestQueue[0]
+			d.requestQueue = d.requestQueue[1:]
+			d.request = &next
+			continue
+		}
+		if d.isDischarging && d.stopReason == "" {
+			d.stopReason = stopReasonRequest
+		}
+	}
+	return false
+}
+
+// cancelRequests drops the active and queued external requests, e.g. on a forced stop by the operator.
+func (d *Discharge) cancelRequests() {
+	if d.request == nil && len(d.requestQueue) == 0 {
+		return
+	}
+	d.logEvent(d.log.With(slog.Int("queued", len(d.requestQueue))), EventControl, "external discharge requests cancelled")
+	d.request = nil
+	d.requestQueue = nil
+}
@@ -34,6 +34,9 @@ type BatteryInfo struct {
 	SystemTime               float64 `json:"systemtime"`
 	SystemWarning            float64 `json:"systemwarning"`
 	UsableRemainingCapacity  float64 `json:"usableremainingcapacity"`
+	// IsSecondLife marks repurposed EV modules; it is not reported by the BMS and comes from configuration
+	IsSecondLife          bool    `json:"issecondlife"`
+	InternalResistanceOhm float64 `json:"internalresistanceohm"`
 }
 
 func ParseBatteryInfo(body []byte) (*BatteryInfo, error) {
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// BatterySnapshot is a reduced point-in-time reading of a battery, suitable for storing history.
+type BatterySnapshot struct {
+	Battery      string    `json:"battery"`
+	Time         time.Time `json:"time"`
+	SoC          float64   `json:"soc"`
+	CapacityWh   float64   `json:"capacity_wh"`
+	PacW         float64   `json:"pac_w"`
+	ConsumptionW float64   `json:"consumption_w"`
+	ProductionW  float64   `json:"production_w"`
+	GridFeedInW  float64   `json:"grid_feed_in_w"`
+}
+
+func NewBatterySnapshot(battery string, t time.Time, status *SystemStatus) BatterySnapshot {
+	return BatterySnapshot{
+		Battery:      battery,
+		Time:         t,
+		SoC:          status.RSOC,
+		CapacityWh:   status.RemainingCapacityWh,
+		PacW:         status.PacTotalW,
+		ConsumptionW: status.ConsumptionW,
+		ProductionW:  status.ProductionW,
+		GridFeedInW:  status.GridFeedInW,
+	}
+}
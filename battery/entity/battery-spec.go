@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// BatterySpec holds the static characteristics of a battery, read once at startup,
+// as opposed to BatteryInfo and SystemStatus which change at runtime.
+type BatterySpec struct {
+	Manufacturer       string    `json:"manufacturer"`
+	Model              string    `json:"model"`
+	SerialNumber       string    `json:"serial_number"`
+	NominalCapacityWh  float64   `json:"nominal_capacity_wh"`
+	NominalVoltage     float64   `json:"nominal_voltage"`
+	MaxChargeRateW     float64   `json:"max_charge_rate_w"`
+	MaxDischargeRateW  float64   `json:"max_discharge_rate_w"`
+	WarrantyExpiryDate time.Time `json:"warranty_expiry_date"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+type BatteryStatus int
+
+const (
+	Idle BatteryStatus = iota
+	Charging
+	Discharging
+	Fault
+	Calibrating
+	Balancing
+	Standby
+)
+
+// BatteryStatuses lists all status values, in order, e.g. to export one metric series per status.
+var BatteryStatuses = []BatteryStatus{Idle, Charging, Discharging, Fault, Calibrating, Balancing, Standby}
+
+func (s BatteryStatus) String() string {
+	switch s {
+	case Idle:
+		return "idle"
+	case Charging:
+		return "charging"
+	case Discharging:
+		return "discharging"
+	case Fault:
+		return "fault"
+	case Calibrating:
+		return "calibrating"
+	case Balancing:
+		return "balancing"
+	case Standby:
+		return "standby"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// DailyBatteryStats summarizes one calendar day of battery operation, as needed for warranty claims.
+type DailyBatteryStats struct {
+	Date               time.Time `json:"date"`
+	MinSoC             int       `json:"min_soc"`
+	MaxSoC             int       `json:"max_soc"`
+	CyclesStarted      int       `json:"cycles_started"`
+	EnergyChargedWh    float64   `json:"energy_charged_wh"`
+	EnergyDischargedWh float64   `json:"energy_discharged_wh"`
+}
+
+// Observe adds a status reading taken at time t; elapsed is the time since the previous reading
+// and is used to integrate the battery power into charged and discharged energy.
+// A reading from a later day starts new statistics. A cycle starts whenever the battery
+// begins to discharge.
+func (s *DailyBatteryStats) Observe(status *SystemStatus, t time.Time, elapsed time.Duration, wasDischarging bool) {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	soc := int(status.RSOC)
+	if !s.Date.Equal(date) {
+		*s = DailyBatteryStats{Date: date, MinSoC: soc, MaxSoC: soc}
+	}
+	s.MinSoC = min(s.MinSoC, soc)
+	s.MaxSoC = max(s.MaxSoC, soc)
+	if status.BatteryDischarging && !wasDischarging {
+		s.CyclesStarted++
+	}
+	// positive AC power means the battery is discharging
+	energy := status.PacTotalW * elapsed.Hours()
+	if energy > 0 {
+		s.EnergyDischargedWh += energy
+	} else {
+		s.EnergyChargedWh -= energy
+	}
+}
@@ -0,0 +1,6 @@
+// Package entity defines the data types exchanged between the battery client, the discharge workers,
+// storage and the API.
+//
+// A SystemStatus read from the battery is owned by the worker that read it. To hand a status to another
+// goroutine, such as a metrics updater or an HTTP handler, pass a copy made with SystemStatus.Clone.
+package entity
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EnergyMeterSnapshot holds the cumulative energy meter readings of the inverter in kWh.
+type EnergyMeterSnapshot struct {
+	GridImportKwh     float64 `json:"grid_import_kwh"`
+	GridExportKwh     float64 `json:"grid_export_kwh"`
+	SelfConsumedKwh   float64 `json:"self_consumed_kwh"`
+	SolarGeneratedKwh float64 `json:"solar_generated_kwh"`
+}
+
+type powerMeter struct {
+	Direction   string  `json:"direction"`
+	KwhImported float64 `json:"kwh_imported"`
+	KwhExported float64 `json:"kwh_exported"`
+}
+
+// ParsePowerMeters reads the power meter list of the battery API. The production meter counts
+// solar generation, the grid meter, if installed, counts energy bought and sold; the self-consumed
+// energy is the part of generation that was not exported.
+func ParsePowerMeters(body []byte) (*EnergyMeterSnapshot, error) {
+	var meters []powerMeter
+	err := json.Unmarshal(body, &meters)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal power meter body: %s", err)
+	}
+	var snapshot EnergyMeterSnapshot
+	for _, meter := range meters {
+		switch meter.Direction {
+		case "production":
+			snapshot.SolarGeneratedKwh = meter.KwhImported
+		case "grid":
+			snapshot.GridImportKwh = meter.KwhImported
+			snapshot.GridExportKwh = meter.KwhExported
+		}
+	}
+	snapshot.SelfConsumedKwh = max(0, snapshot.SolarGeneratedKwh-snapshot.GridExportKwh)
+	return &snapshot, nil
+}
@@ -0,0 +1,25 @@
+package entity
+
+// OperatingMode is the inverter operating mode, with values as used by the EM_OperatingMode setting.
+type OperatingMode string
+
+const (
+	Manual    OperatingMode = "1"
+	Automatic OperatingMode = "2"
+	TimeOfUse OperatingMode = "10"
+	// SelfConsumption is the automatic mode, which maximises self-consumption of the produced energy.
+	SelfConsumption = Automatic
+)
+
+func (m OperatingMode) String() string {
+	switch m {
+	case Manual:
+		return "manual"
+	case Automatic:
+		return "automatic"
+	case TimeOfUse:
+		return "time-of-use"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,829 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: battery.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SystemStatus mirrors entity.SystemStatus, the live status reported by the battery API.
+// Timestamp is the reading time, converted from the local time string of the API.
+type SystemStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApparentOutput            float64                `protobuf:"fixed64,1,opt,name=apparent_output,json=apparentOutput,proto3" json:"apparent_output,omitempty"`
+	BackupBuffer              string                 `protobuf:"bytes,2,opt,name=backup_buffer,json=backupBuffer,proto3" json:"backup_buffer,omitempty"`
+	BatteryCharging           bool                   `protobuf:"varint,3,opt,name=battery_charging,json=batteryCharging,proto3" json:"battery_charging,omitempty"`
+	BatteryDischarging        bool                   `protobuf:"varint,4,opt,name=battery_discharging,json=batteryDischarging,proto3" json:"battery_discharging,omitempty"`
+	ConsumptionAvg            float64                `protobuf:"fixed64,5,opt,name=consumption_avg,json=consumptionAvg,proto3" json:"consumption_avg,omitempty"`
+	ConsumptionW              float64                `protobuf:"fixed64,6,opt,name=consumption_w,json=consumptionW,proto3" json:"consumption_w,omitempty"`
+	Fac                       float64                `protobuf:"fixed64,7,opt,name=fac,proto3" json:"fac,omitempty"`
+	FlowConsumptionBattery    bool                   `protobuf:"varint,8,opt,name=flow_consumption_battery,json=flowConsumptionBattery,proto3" json:"flow_consumption_battery,omitempty"`
+	FlowConsumptionGrid       bool                   `protobuf:"varint,9,opt,name=flow_consumption_grid,json=flowConsumptionGrid,proto3" json:"flow_consumption_grid,omitempty"`
+	FlowConsumptionProduction bool                   `protobuf:"varint,10,opt,name=flow_consumption_production,json=flowConsumptionProduction,proto3" json:"flow_consumption_production,omitempty"`
+	FlowGridBattery           bool                   `protobuf:"varint,11,opt,name=flow_grid_battery,json=flowGridBattery,proto3" json:"flow_grid_battery,omitempty"`
+	FlowProductionBattery     bool                   `protobuf:"varint,12,opt,name=flow_production_battery,json=flowProductionBattery,proto3" json:"flow_production_battery,omitempty"`
+	FlowProductionGrid        bool                   `protobuf:"varint,13,opt,name=flow_production_grid,json=flowProductionGrid,proto3" json:"flow_production_grid,omitempty"`
+	GridFeedInW               float64                `protobuf:"fixed64,14,opt,name=grid_feed_in_w,json=gridFeedInW,proto3" json:"grid_feed_in_w,omitempty"`
+	IsSystemInstalled         float64                `protobuf:"fixed64,15,opt,name=is_system_installed,json=isSystemInstalled,proto3" json:"is_system_installed,omitempty"`
+	OperatingMode             string                 `protobuf:"bytes,16,opt,name=operating_mode,json=operatingMode,proto3" json:"operating_mode,omitempty"`
+	PacTotalW                 float64                `protobuf:"fixed64,17,opt,name=pac_total_w,json=pacTotalW,proto3" json:"pac_total_w,omitempty"`
+	ProductionW               float64                `protobuf:"fixed64,18,opt,name=production_w,json=productionW,proto3" json:"production_w,omitempty"`
+	Rsoc                      float64                `protobuf:"fixed64,19,opt,name=rsoc,proto3" json:"rsoc,omitempty"`
+	RemainingCapacityWh       float64                `protobuf:"fixed64,20,opt,name=remaining_capacity_wh,json=remainingCapacityWh,proto3" json:"remaining_capacity_wh,omitempty"`
+	Sac1                      float64                `protobuf:"fixed64,21,opt,name=sac1,proto3" json:"sac1,omitempty"`
+	SystemStatus              string                 `protobuf:"bytes,24,opt,name=system_status,json=systemStatus,proto3" json:"system_status,omitempty"`
+	Timestamp                 *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Usoc                      float64                `protobuf:"fixed64,26,opt,name=usoc,proto3" json:"usoc,omitempty"`
+	Uac                       float64                `protobuf:"fixed64,27,opt,name=uac,proto3" json:"uac,omitempty"`
+	Ubat                      float64                `protobuf:"fixed64,28,opt,name=ubat,proto3" json:"ubat,omitempty"`
+	DischargeNotAllowed       bool                   `protobuf:"varint,29,opt,name=discharge_not_allowed,json=dischargeNotAllowed,proto3" json:"discharge_not_allowed,omitempty"`
+	GeneratorAutostart        bool                   `protobuf:"varint,30,opt,name=generator_autostart,json=generatorAutostart,proto3" json:"generator_autostart,omitempty"`
+}
+
+func (x *SystemStatus) Reset() {
+	*x = SystemStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_battery_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStatus) ProtoMessage() {}
+
+func (x *SystemStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_battery_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
+func (*SystemStatus) Descriptor() ([]byte, []int) {
+	return file_battery_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SystemStatus) GetApparentOutput() float64 {
+	if x != nil {
+		return x.ApparentOutput
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetBackupBuffer() string {
+	if x != nil {
+		return x.BackupBuffer
+	}
+	return ""
+}
+
+func (x *SystemStatus) GetBatteryCharging() bool {
+	if x != nil {
+		return x.BatteryCharging
+	}
+	return false
+}
+
+func (x *SystemStatus) GetBatteryDischarging() bool {
+	if x != nil {
+		return x.BatteryDischarging
+	}
+	return false
+}
+
+func (x *SystemStatus) GetConsumptionAvg() float64 {
+	if x != nil {
+		return x.ConsumptionAvg
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetConsumptionW() float64 {
+	if x != nil {
+		return x.ConsumptionW
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetFac() float64 {
+	if x != nil {
+		return x.Fac
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetFlowConsumptionBattery() bool {
+	if x != nil {
+		return x.FlowConsumptionBattery
+	}
+	return false
+}
+
+func (x *SystemStatus) GetFlowConsumptionGrid() bool {
+	if x != nil {
+		return x.FlowConsumptionGrid
+	}
+	return false
+}
+
+func (x *SystemStatus) GetFlowConsumptionProduction() bool {
+	if x != nil {
+		return x.FlowConsumptionProduction
+	}
+	return false
+}
+
+func (x *SystemStatus) GetFlowGridBattery() bool {
+	if x != nil {
+		return x.FlowGridBattery
+	}
+	return false
+}
+
+func (x *SystemStatus) GetFlowProductionBattery() bool {
+	if x != nil {
+		return x.FlowProductionBattery
+	}
+	return false
+}
+
+func (x *SystemStatus) GetFlowProductionGrid() bool {
+	if x != nil {
+		return x.FlowProductionGrid
+	}
+	return false
+}
+
+func (x *SystemStatus) GetGridFeedInW() float64 {
+	if x != nil {
+		return x.GridFeedInW
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetIsSystemInstalled() float64 {
+	if x != nil {
+		return x.IsSystemInstalled
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetOperatingMode() string {
+	if x != nil {
+		return x.OperatingMode
+	}
+	return ""
+}
+
+func (x *SystemStatus) GetPacTotalW() float64 {
+	if x != nil {
+		return x.PacTotalW
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetProductionW() float64 {
+	if x != nil {
+		return x.ProductionW
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetRsoc() float64 {
+	if x != nil {
+		return x.Rsoc
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetRemainingCapacityWh() float64 {
+	if x != nil {
+		return x.RemainingCapacityWh
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetSac1() float64 {
+	if x != nil {
+		return x.Sac1
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetSystemStatus() string {
+	if x != nil {
+		return x.SystemStatus
+	}
+	return ""
+}
+
+func (x *SystemStatus) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetUsoc() float64 {
+	if x != nil {
+		return x.Usoc
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetUac() float64 {
+	if x != nil {
+		return x.Uac
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetUbat() float64 {
+	if x != nil {
+		return x.Ubat
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetDischargeNotAllowed() bool {
+	if x != nil {
+		return x.DischargeNotAllowed
+	}
+	return false
+}
+
+func (x *SystemStatus) GetGeneratorAutostart() bool {
+	if x != nil {
+		return x.GeneratorAutostart
+	}
+	return false
+}
+
+// BatteryInfo mirrors entity.BatteryInfo, the module data reported by the BMS. Counts and status codes,
+// which the API reports as numbers, are unsigned integers; SystemTime is the BMS clock in Unix seconds.
+type BatteryInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BalanceChargeRequest     bool                   `protobuf:"varint,1,opt,name=balance_charge_request,json=balanceChargeRequest,proto3" json:"balance_charge_request,omitempty"`
+	ChargeCurrentLimit       float64                `protobuf:"fixed64,2,opt,name=charge_current_limit,json=chargeCurrentLimit,proto3" json:"charge_current_limit,omitempty"`
+	CycleCount               uint32                 `protobuf:"varint,3,opt,name=cycle_count,json=cycleCount,proto3" json:"cycle_count,omitempty"`
+	DischargeCurrentLimit    float64                `protobuf:"fixed64,4,opt,name=discharge_current_limit,json=dischargeCurrentLimit,proto3" json:"discharge_current_limit,omitempty"`
+	FullChargeCapacity       float64                `protobuf:"fixed64,5,opt,name=full_charge_capacity,json=fullChargeCapacity,proto3" json:"full_charge_capacity,omitempty"`
+	FullChargeCapacityWh     float64                `protobuf:"fixed64,6,opt,name=full_charge_capacity_wh,json=fullChargeCapacityWh,proto3" json:"full_charge_capacity_wh,omitempty"`
+	MaximumCellTemperature   float64                `protobuf:"fixed64,7,opt,name=maximum_cell_temperature,json=maximumCellTemperature,proto3" json:"maximum_cell_temperature,omitempty"`
+	MaximumCellVoltage       float64                `protobuf:"fixed64,8,opt,name=maximum_cell_voltage,json=maximumCellVoltage,proto3" json:"maximum_cell_voltage,omitempty"`
+	MaximumCellVoltageNum    uint32                 `protobuf:"varint,9,opt,name=maximum_cell_voltage_num,json=maximumCellVoltageNum,proto3" json:"maximum_cell_voltage_num,omitempty"`
+	MaximumModuleCurrent     float64                `protobuf:"fixed64,10,opt,name=maximum_module_current,json=maximumModuleCurrent,proto3" json:"maximum_module_current,omitempty"`
+	MaximumModuleDcVoltage   float64                `protobuf:"fixed64,11,opt,name=maximum_module_dc_voltage,json=maximumModuleDcVoltage,proto3" json:"maximum_module_dc_voltage,omitempty"`
+	MaximumModuleTemperature float64                `protobuf:"fixed64,12,opt,name=maximum_module_temperature,json=maximumModuleTemperature,proto3" json:"maximum_module_temperature,omitempty"`
+	MinimumCellTemperature   float64                `protobuf:"fixed64,13,opt,name=minimum_cell_temperature,json=minimumCellTemperature,proto3" json:"minimum_cell_temperature,omitempty"`
+	MinimumCellVoltage       float64                `protobuf:"fixed64,14,opt,name=minimum_cell_voltage,json=minimumCellVoltage,proto3" json:"minimum_cell_voltage,omitempty"`
+	MinimumCellVoltageNum    uint32                 `protobuf:"varint,15,opt,name=minimum_cell_voltage_num,json=minimumCellVoltageNum,proto3" json:"minimum_cell_voltage_num,omitempty"`
+	MinimumModuleCurrent     float64                `protobuf:"fixed64,16,opt,name=minimum_module_current,json=minimumModuleCurrent,proto3" json:"minimum_module_current,omitempty"`
+	MinimumModuleDcVoltage   float64                `protobuf:"fixed64,17,opt,name=minimum_module_dc_voltage,json=minimumModuleDcVoltage,proto3" json:"minimum_module_dc_voltage,omitempty"`
+	MinimumModuleTemperature float64                `protobuf:"fixed64,18,opt,name=minimum_module_temperature,json=minimumModuleTemperature,proto3" json:"minimum_module_temperature,omitempty"`
+	NominalModuleDcVoltage   float64                `protobuf:"fixed64,19,opt,name=nominal_module_dc_voltage,json=nominalModuleDcVoltage,proto3" json:"nominal_module_dc_voltage,omitempty"`
+	RelativeStateOfCharge    float64                `protobuf:"fixed64,20,opt,name=relative_state_of_charge,json=relativeStateOfCharge,proto3" json:"relative_state_of_charge,omitempty"`
+	RemainingCapacity        float64                `protobuf:"fixed64,21,opt,name=remaining_capacity,json=remainingCapacity,proto3" json:"remaining_capacity,omitempty"`
+	SystemAlarm              uint32                 `protobuf:"varint,22,opt,name=system_alarm,json=systemAlarm,proto3" json:"system_alarm,omitempty"`
+	SystemCurrent            float64                `protobuf:"fixed64,23,opt,name=system_current,json=systemCurrent,proto3" json:"system_current,omitempty"`
+	SystemDcVoltage          float64                `protobuf:"fixed64,24,opt,name=system_dc_voltage,json=systemDcVoltage,proto3" json:"system_dc_voltage,omitempty"`
+	SystemStatus             uint32                 `protobuf:"varint,25,opt,name=system_status,json=systemStatus,proto3" json:"system_status,omitempty"`
+	SystemTime               *timestamppb.Timestamp `protobuf:"bytes,26,opt,name=system_time,json=systemTime,proto3" json:"system_time,omitempty"`
+	SystemWarning            uint32                 `protobuf:"varint,27,opt,name=system_warning,json=systemWarning,proto3" json:"system_warning,omitempty"`
+	UsableRemainingCapacity  float64                `protobuf:"fixed64,28,opt,name=usable_remaining_capacity,json=usableRemainingCapacity,proto3" json:"usable_remaining_capacity,omitempty"`
+	IsSecondLife             bool                   `protobuf:"varint,29,opt,name=is_second_life,json=isSecondLife,proto3" json:"is_second_life,omitempty"`
+	InternalResistanceOhm    float64                `protobuf:"fixed64,30,opt,name=internal_resistance_ohm,json=internalResistanceOhm,proto3" json:"internal_resistance_ohm,omitempty"`
+}
+
+func (x *BatteryInfo) Reset() {
+	*x = BatteryInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_battery_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatteryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatteryInfo) ProtoMessage() {}
+
+func (x *BatteryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_battery_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatteryInfo.ProtoReflect.Descriptor instead.
+func (*BatteryInfo) Descriptor() ([]byte, []int) {
+	return file_battery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatteryInfo) GetBalanceChargeRequest() bool {
+	if x != nil {
+		return x.BalanceChargeRequest
+	}
+	return false
+}
+
+func (x *BatteryInfo) GetChargeCurrentLimit() float64 {
+	if x != nil {
+		return x.ChargeCurrentLimit
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetCycleCount() uint32 {
+	if x != nil {
+		return x.CycleCount
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetDischargeCurrentLimit() float64 {
+	if x != nil {
+		return x.DischargeCurrentLimit
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetFullChargeCapacity() float64 {
+	if x != nil {
+		return x.FullChargeCapacity
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetFullChargeCapacityWh() float64 {
+	if x != nil {
+		return x.FullChargeCapacityWh
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMaximumCellTemperature() float64 {
+	if x != nil {
+		return x.MaximumCellTemperature
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMaximumCellVoltage() float64 {
+	if x != nil {
+		return x.MaximumCellVoltage
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMaximumCellVoltageNum() uint32 {
+	if x != nil {
+		return x.MaximumCellVoltageNum
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMaximumModuleCurrent() float64 {
+	if x != nil {
+		return x.MaximumModuleCurrent
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMaximumModuleDcVoltage() float64 {
+	if x != nil {
+		return x.MaximumModuleDcVoltage
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMaximumModuleTemperature() float64 {
+	if x != nil {
+		return x.MaximumModuleTemperature
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMinimumCellTemperature() float64 {
+	if x != nil {
+		return x.MinimumCellTemperature
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMinimumCellVoltage() float64 {
+	if x != nil {
+		return x.MinimumCellVoltage
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMinimumCellVoltageNum() uint32 {
+	if x != nil {
+		return x.MinimumCellVoltageNum
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMinimumModuleCurrent() float64 {
+	if x != nil {
+		return x.MinimumModuleCurrent
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMinimumModuleDcVoltage() float64 {
+	if x != nil {
+		return x.MinimumModuleDcVoltage
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetMinimumModuleTemperature() float64 {
+	if x != nil {
+		return x.MinimumModuleTemperature
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetNominalModuleDcVoltage() float64 {
+	if x != nil {
+		return x.NominalModuleDcVoltage
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetRelativeStateOfCharge() float64 {
+	if x != nil {
+		return x.RelativeStateOfCharge
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetRemainingCapacity() float64 {
+	if x != nil {
+		return x.RemainingCapacity
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetSystemAlarm() uint32 {
+	if x != nil {
+		return x.SystemAlarm
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetSystemCurrent() float64 {
+	if x != nil {
+		return x.SystemCurrent
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetSystemDcVoltage() float64 {
+	if x != nil {
+		return x.SystemDcVoltage
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetSystemStatus() uint32 {
+	if x != nil {
+		return x.SystemStatus
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetSystemTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SystemTime
+	}
+	return nil
+}
+
+func (x *BatteryInfo) GetSystemWarning() uint32 {
+	if x != nil {
+		return x.SystemWarning
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetUsableRemainingCapacity() float64 {
+	if x != nil {
+		return x.UsableRemainingCapacity
+	}
+	return 0
+}
+
+func (x *BatteryInfo) GetIsSecondLife() bool {
+	if x != nil {
+		return x.IsSecondLife
+	}
+	return false
+}
+
+func (x *BatteryInfo) GetInternalResistanceOhm() float64 {
+	if x != nil {
+		return x.InternalResistanceOhm
+	}
+	return 0
+}
+
+var File_battery_proto protoreflect.FileDescriptor
+
+var file_battery_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0d, 0x67, 0x6f, 0x6b, 0x70, 0x69, 0x2e, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x81, 0x09, 0x0a, 0x0c, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x70, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x29,
+	0x0a, 0x10, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69,
+	0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x79, 0x43, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x2f, 0x0a, 0x13, 0x62, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x44,
+	0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x76, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x41, 0x76, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x73,
+	0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x61, 0x63, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x66, 0x61, 0x63, 0x12, 0x38, 0x0a, 0x18, 0x66, 0x6c,
+	0x6f, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x66, 0x6c,
+	0x6f, 0x77, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x67, 0x72, 0x69, 0x64, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x13, 0x66, 0x6c, 0x6f, 0x77, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x69, 0x64, 0x12, 0x3e, 0x0a, 0x1b, 0x66, 0x6c, 0x6f, 0x77,
+	0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x66,
+	0x6c, 0x6f, 0x77, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x6c, 0x6f, 0x77,
+	0x5f, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x66, 0x6c, 0x6f, 0x77, 0x47, 0x72, 0x69, 0x64, 0x42, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x17, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x14,
+	0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x67, 0x72, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x66, 0x6c, 0x6f, 0x77,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x69, 0x64, 0x12, 0x23,
+	0x0a, 0x0e, 0x67, 0x72, 0x69, 0x64, 0x5f, 0x66, 0x65, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x77,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x67, 0x72, 0x69, 0x64, 0x46, 0x65, 0x65, 0x64,
+	0x49, 0x6e, 0x57, 0x12, 0x2e, 0x0a, 0x13, 0x69, 0x73, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x11, 0x69, 0x73, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x61,
+	0x63, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x77, 0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x70, 0x61, 0x63, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x77, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x73, 0x6f, 0x63, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x73, 0x6f,
+	0x63, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x63,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x77, 0x68, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x13, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x57, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x63, 0x31, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x73, 0x61, 0x63, 0x31, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x19, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x6f, 0x63,
+	0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x75, 0x73, 0x6f, 0x63, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x61, 0x63, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x75, 0x61, 0x63, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x62, 0x61, 0x74, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x75, 0x62,
+	0x61, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f,
+	0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x1d, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x13, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x4e, 0x6f, 0x74, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x1e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x41, 0x75,
+	0x74, 0x6f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x04, 0x08, 0x16, 0x10, 0x18, 0x4a, 0x04, 0x08,
+	0x1f, 0x10, 0x32, 0x22, 0xa1, 0x0c, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x34, 0x0a, 0x16, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x63,
+	0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x14, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x72,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x68, 0x61,
+	0x72, 0x67, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x17,
+	0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x64,
+	0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x68, 0x61,
+	0x72, 0x67, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x12, 0x66, 0x75, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x43, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x35, 0x0a, 0x17, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x63,
+	0x68, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x77,
+	0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x66, 0x75, 0x6c, 0x6c, 0x43, 0x68, 0x61,
+	0x72, 0x67, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x57, 0x68, 0x12, 0x38, 0x0a,
+	0x18, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x54, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x69, 0x6d,
+	0x75, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x65,
+	0x6c, 0x6c, 0x56, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x6d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67,
+	0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x15, 0x6d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x56, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x4e,
+	0x75, 0x6d, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x6d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x4d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x19, 0x6d, 0x61, 0x78, 0x69,
+	0x6d, 0x75, 0x6d, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x64, 0x63, 0x5f, 0x76, 0x6f,
+	0x6c, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16, 0x6d, 0x61, 0x78,
+	0x69, 0x6d, 0x75, 0x6d, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x44, 0x63, 0x56, 0x6f, 0x6c, 0x74,
+	0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x63, 0x65, 0x6c,
+	0x6c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x16, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x65, 0x6c, 0x6c,
+	0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6d,
+	0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x74,
+	0x61, 0x67, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6d, 0x69, 0x6e, 0x69, 0x6d,
+	0x75, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x56, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a,
+	0x18, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x63, 0x65, 0x6c, 0x6c, 0x5f, 0x76, 0x6f,
+	0x6c, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x15, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x43, 0x65, 0x6c, 0x6c, 0x56, 0x6f, 0x6c, 0x74,
+	0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x34, 0x0a, 0x16, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75,
+	0x6d, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x4d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x19,
+	0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x64,
+	0x63, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x16, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x44, 0x63,
+	0x56, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x6d, 0x69, 0x6e, 0x69, 0x6d,
+	0x75, 0x6d, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x6d, 0x69, 0x6e,
+	0x69, 0x6d, 0x75, 0x6d, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x39, 0x0a, 0x19, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x6c,
+	0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x64, 0x63, 0x5f, 0x76, 0x6f, 0x6c, 0x74, 0x61,
+	0x67, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61,
+	0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x44, 0x63, 0x56, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x37, 0x0a, 0x18, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x15, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x4f, 0x66, 0x43, 0x68, 0x61, 0x72, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x5f, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x64, 0x63, 0x5f,
+	0x76, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x44, 0x63, 0x56, 0x6f, 0x6c, 0x74, 0x61, 0x67, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x3a, 0x0a, 0x19, 0x75, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x75, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x5f, 0x6c, 0x69, 0x66, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x4c, 0x69, 0x66, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x6f, 0x68, 0x6d, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4f, 0x68,
+	0x6d, 0x4a, 0x04, 0x08, 0x1f, 0x10, 0x32, 0x42, 0x1a, 0x5a, 0x18, 0x67, 0x6f, 0x6b, 0x2d, 0x70,
+	0x69, 0x2f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_battery_proto_rawDescOnce sync.Once
+	file_battery_proto_rawDescData = file_battery_proto_rawDesc
+)
+
+func file_battery_proto_rawDescGZIP() []byte {
+	file_battery_proto_rawDescOnce.Do(func() {
+		file_battery_proto_rawDescData = protoimpl.X.CompressGZIP(file_battery_proto_rawDescData)
+	})
+	return file_battery_proto_rawDescData
+}
+
+var file_battery_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_battery_proto_goTypes = []any{
+	(*SystemStatus)(nil),          // 0: gokpi.battery.SystemStatus
+	(*BatteryInfo)(nil),           // 1: gokpi.battery.BatteryInfo
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_battery_proto_depIdxs = []int32{
+	2, // 0: gokpi.battery.SystemStatus.timestamp:type_name -> google.protobuf.Timestamp
+	2, // 1: gokpi.battery.BatteryInfo.system_time:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_battery_proto_init() }
+func file_battery_proto_init() {
+	if File_battery_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_battery_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_battery_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*BatteryInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_battery_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_battery_proto_goTypes,
+		DependencyIndexes: file_battery_proto_depIdxs,
+		MessageInfos:      file_battery_proto_msgTypes,
+	}.Build()
+	File_battery_proto = out.File
+	file_battery_proto_rawDesc = nil
+	file_battery_proto_goTypes = nil
+	file_battery_proto_depIdxs = nil
+}
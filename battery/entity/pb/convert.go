@@ -0,0 +1,175 @@
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative battery.proto
+
+import (
+	"gok-pi/battery/entity"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"time"
+)
+
+// timestampLayout is the format of the Timestamp field of the battery API, in local time.
+const timestampLayout = "2006-01-02 15:04:05"
+
+// FromSystemStatus converts a status reading to its protobuf message. A timestamp that cannot be parsed is left unset.
+func FromSystemStatus(s *entity.SystemStatus) *SystemStatus {
+	msg := &SystemStatus{
+		ApparentOutput:            s.ApparentOutput,
+		BackupBuffer:              s.BackupBuffer,
+		BatteryCharging:           s.BatteryCharging,
+		BatteryDischarging:        s.BatteryDischarging,
+		ConsumptionAvg:            s.ConsumptionAvg,
+		ConsumptionW:              s.ConsumptionW,
+		Fac:                       s.Fac,
+		FlowConsumptionBattery:    s.FlowConsumptionBattery,
+		FlowConsumptionGrid:       s.FlowConsumptionGrid,
+		FlowConsumptionProduction: s.FlowConsumptionProduction,
+		FlowGridBattery:           s.FlowGridBattery,
+		FlowProductionBattery:     s.FlowProductionBattery,
+		FlowProductionGrid:        s.FlowProductionGrid,
+		GridFeedInW:               s.GridFeedInW,
+		IsSystemInstalled:         s.IsSystemInstalled,
+		OperatingMode:             s.OperatingMode,
+		PacTotalW:                 s.PacTotalW,
+		ProductionW:               s.ProductionW,
+		Rsoc:                      s.RSOC,
+		RemainingCapacityWh:       s.RemainingCapacityWh,
+		Sac1:                      s.Sac1,
+		SystemStatus:              s.SystemStatus,
+		Usoc:                      s.USOC,
+		Uac:                       s.Uac,
+		Ubat:                      s.Ubat,
+		DischargeNotAllowed:       s.DischargeNotAllowed,
+		GeneratorAutostart:        s.GeneratorAutostart,
+	}
+	if t, err := time.ParseInLocation(timestampLayout, s.Timestamp, time.Local); err == nil {
+		msg.Timestamp = timestamppb.New(t)
+	}
+	return msg
+}
+
+// ToSystemStatus converts a protobuf message back to a status reading.
+func ToSystemStatus(msg *SystemStatus) *entity.SystemStatus {
+	s := &entity.SystemStatus{
+		ApparentOutput:            msg.GetApparentOutput(),
+		BackupBuffer:              msg.GetBackupBuffer(),
+		BatteryCharging:           msg.GetBatteryCharging(),
+		BatteryDischarging:        msg.GetBatteryDischarging(),
+		ConsumptionAvg:            msg.GetConsumptionAvg(),
+		ConsumptionW:              msg.GetConsumptionW(),
+		Fac:                       msg.GetFac(),
+		FlowConsumptionBattery:    msg.GetFlowConsumptionBattery(),
+		FlowConsumptionGrid:       msg.GetFlowConsumptionGrid(),
+		FlowConsumptionProduction: msg.GetFlowConsumptionProduction(),
+		FlowGridBattery:           msg.GetFlowGridBattery(),
+		FlowProductionBattery:     msg.GetFlowProductionBattery(),
+		FlowProductionGrid:        msg.GetFlowProductionGrid(),
+		GridFeedInW:               msg.GetGridFeedInW(),
+		IsSystemInstalled:         msg.GetIsSystemInstalled(),
+		OperatingMode:             msg.GetOperatingMode(),
+		PacTotalW:                 msg.GetPacTotalW(),
+		ProductionW:               msg.GetProductionW(),
+		RSOC:                      msg.GetRsoc(),
+		RemainingCapacityWh:       msg.GetRemainingCapacityWh(),
+		Sac1:                      msg.GetSac1(),
+		SystemStatus:              msg.GetSystemStatus(),
+		USOC:                      msg.GetUsoc(),
+		Uac:                       msg.GetUac(),
+		Ubat:                      msg.GetUbat(),
+		DischargeNotAllowed:       msg.GetDischargeNotAllowed(),
+		GeneratorAutostart:        msg.GetGeneratorAutostart(),
+	}
+	if msg.GetTimestamp() != nil {
+		s.Timestamp = msg.GetTimestamp().AsTime().In(time.Local).Format(timestampLayout)
+	}
+	return s
+}
+
+// FromBatteryInfo converts the BMS module data to its protobuf message. Counts and status codes are
+// rounded to integers; a zero system time is left unset.
+func FromBatteryInfo(info *entity.BatteryInfo) *BatteryInfo {
+	msg := &BatteryInfo{
+		BalanceChargeRequest:     info.BalanceChargeRequest != 0,
+		ChargeCurrentLimit:       info.ChargeCurrentLimit,
+		CycleCount:               toUint32(info.CycleCount),
+		DischargeCurrentLimit:    info.DischargeCurrentLimit,
+		FullChargeCapacity:       info.FullChargeCapacity,
+		FullChargeCapacityWh:     info.FullChargeCapacityWh,
+		MaximumCellTemperature:   info.MaximumCellTemperature,
+		MaximumCellVoltage:       info.MaximumCellVoltage,
+		MaximumCellVoltageNum:    toUint32(info.MaximumCellVoltageNum),
+		MaximumModuleCurrent:     info.MaximumModuleCurrent,
+		MaximumModuleDcVoltage:   info.MaximumModuleDcVoltage,
+		MaximumModuleTemperature: info.MaximumModuleTemperature,
+		MinimumCellTemperature:   info.MinimumCellTemperature,
+		MinimumCellVoltage:       info.MinimumCellVoltage,
+		MinimumCellVoltageNum:    toUint32(info.MinimumCellVoltageNum),
+		MinimumModuleCurrent:     info.MinimumModuleCurrent,
+		MinimumModuleDcVoltage:   info.MinimumModuleDcVoltage,
+		MinimumModuleTemperature: info.MinimumModuleTemperature,
+		NominalModuleDcVoltage:   info.NominalModuleDcVoltage,
+		RelativeStateOfCharge:    info.RelativeStateOfCharge,
+		RemainingCapacity:        info.RemainingCapacity,
+		SystemAlarm:              toUint32(info.SystemAlarm),
+		SystemCurrent:            info.SystemCurrent,
+		SystemDcVoltage:          info.SystemDcVoltage,
+		SystemStatus:             toUint32(info.SystemStatus),
+		SystemWarning:            toUint32(info.SystemWarning),
+		UsableRemainingCapacity:  info.UsableRemainingCapacity,
+		IsSecondLife:             info.IsSecondLife,
+		InternalResistanceOhm:    info.InternalResistanceOhm,
+	}
+	if info.SystemTime > 0 {
+		sec, frac := math.Modf(info.SystemTime)
+		msg.SystemTime = timestamppb.New(time.Unix(int64(sec), int64(frac*1e9)))
+	}
+	return msg
+}
+
+// ToBatteryInfo converts a protobuf message back to the BMS module data.
+func ToBatteryInfo(msg *BatteryInfo) *entity.BatteryInfo {
+	info := &entity.BatteryInfo{
+		ChargeCurrentLimit:       msg.GetChargeCurrentLimit(),
+		CycleCount:               float64(msg.GetCycleCount()),
+		DischargeCurrentLimit:    msg.GetDischargeCurrentLimit(),
+		FullChargeCapacity:       msg.GetFullChargeCapacity(),
+		FullChargeCapacityWh:     msg.GetFullChargeCapacityWh(),
+		MaximumCellTemperature:   msg.GetMaximumCellTemperature(),
+		MaximumCellVoltage:       msg.GetMaximumCellVoltage(),
+		MaximumCellVoltageNum:    float64(msg.GetMaximumCellVoltageNum()),
+		MaximumModuleCurrent:     msg.GetMaximumModuleCurrent(),
+		MaximumModuleDcVoltage:   msg.GetMaximumModuleDcVoltage(),
+		MaximumModuleTemperature: msg.GetMaximumModuleTemperature(),
+		MinimumCellTemperature:   msg.GetMinimumCellTemperature(),
+		MinimumCellVoltage:       msg.GetMinimumCellVoltage(),
+		MinimumCellVoltageNum:    float64(msg.GetMinimumCellVoltageNum()),
+		MinimumModuleCurrent:     msg.GetMinimumModuleCurrent(),
+		MinimumModuleDcVoltage:   msg.GetMinimumModuleDcVoltage(),
+		MinimumModuleTemperature: msg.GetMinimumModuleTemperature(),
+		NominalModuleDcVoltage:   msg.GetNominalModuleDcVoltage(),
+		RelativeStateOfCharge:    msg.GetRelativeStateOfCharge(),
+		RemainingCapacity:        msg.GetRemainingCapacity(),
+		SystemAlarm:              float64(msg.GetSystemAlarm()),
+		SystemCurrent:            msg.GetSystemCurrent(),
+		SystemDcVoltage:          msg.GetSystemDcVoltage(),
+		SystemStatus:             float64(msg.GetSystemStatus()),
+		SystemWarning:            float64(msg.GetSystemWarning()),
+		UsableRemainingCapacity:  msg.GetUsableRemainingCapacity(),
+		IsSecondLife:             msg.GetIsSecondLife(),
+		InternalResistanceOhm:    msg.GetInternalResistanceOhm(),
+	}
+	if msg.GetBalanceChargeRequest() {
+		info.BalanceChargeRequest = 1
+	}
+	if msg.GetSystemTime() != nil {
+		t := msg.GetSystemTime().AsTime()
+		info.SystemTime = float64(t.Unix()) + float64(t.Nanosecond())/1e9
+	}
+	return info
+}
+
+// toUint32 rounds a count or status code reported as a number; negative values are clamped to zero.
+func toUint32(v float64) uint32 {
+	return uint32(math.Round(max(v, 0)))
+}
@@ -0,0 +1,67 @@
+package pb
+
+import (
+	"gok-pi/battery/entity"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestBatteryInfoRoundTrip(t *testing.T) {
+	info := entity.BatteryInfo{
+		BalanceChargeRequest:     1,
+		ChargeCurrentLimit:       39.97,
+		CycleCount:               652,
+		DischargeCurrentLimit:    39.97,
+		FullChargeCapacity:       201.98,
+		FullChargeCapacityWh:     10277.03,
+		MaximumCellTemperature:   23.95,
+		MaximumCellVoltage:       3.32,
+		MaximumCellVoltageNum:    3,
+		MaximumModuleCurrent:     -1.1,
+		MaximumModuleDcVoltage:   53.15,
+		MaximumModuleTemperature: 23.7,
+		MinimumCellTemperature:   22.75,
+		MinimumCellVoltage:       3.31,
+		MinimumCellVoltageNum:    12,
+		MinimumModuleCurrent:     -1.15,
+		MinimumModuleDcVoltage:   53.1,
+		MinimumModuleTemperature: 22.9,
+		NominalModuleDcVoltage:   51.2,
+		RelativeStateOfCharge:    64,
+		RemainingCapacity:        128.91,
+		SystemAlarm:              2,
+		SystemCurrent:            -9.96,
+		SystemDcVoltage:          209.22,
+		SystemStatus:             49,
+		SystemTime:               1732127439,
+		SystemWarning:            4,
+		UsableRemainingCapacity:  122.77,
+		IsSecondLife:             true,
+		InternalResistanceOhm:    0.08,
+	}
+
+	data, err := proto.Marshal(FromBatteryInfo(&info))
+	if err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	var msg BatteryInfo
+	if err = proto.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	if got := ToBatteryInfo(&msg); *got != info {
+		t.Errorf("round trip\n got: %+v\nwant: %+v", *got, info)
+	}
+	if msg.GetSystemTime().AsTime().Unix() != 1732127439 {
+		t.Errorf("system time %v, want Unix 1732127439", msg.GetSystemTime().AsTime())
+	}
+}
+
+func TestBatteryInfoUnsetTime(t *testing.T) {
+	msg := FromBatteryInfo(&entity.BatteryInfo{CycleCount: -1})
+	if msg.GetSystemTime() != nil {
+		t.Errorf("system time %v, want unset", msg.GetSystemTime())
+	}
+	if msg.GetCycleCount() != 0 {
+		t.Errorf("cycle count %d, want a negative reading clamped to 0", msg.GetCycleCount())
+	}
+}
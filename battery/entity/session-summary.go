@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// SessionSummary describes one discharge session, from the start command to the stop command.
+// PeakPrice and CarbonIntensity are sampled at session start, in currency/kWh and gCO2/kWh.
+type SessionSummary struct {
+	Battery         string    `json:"battery"`
+	StartTime       time.Time `json:"start_time"`
+	StopTime        time.Time `json:"stop_time"`
+	StartSoC        float64   `json:"start_soc"`
+	EndSoC          float64   `json:"end_soc"`
+	SocLimit        float64   `json:"soc_limit"`
+	EnergyWh        float64   `json:"energy_wh"`
+	PeakPrice       float64   `json:"peak_price"`
+	CostSaved       float64   `json:"cost_saved"`
+	CarbonIntensity float64   `json:"carbon_intensity"`
+	CO2SavedKg      float64   `json:"co2_saved_kg"`
+	StopReason      string    `json:"stop_reason"`
+}
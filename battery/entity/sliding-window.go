@@ -0,0 +1,54 @@
+package entity
+
+// SlidingWindow keeps the most recent values up to a fixed size, oldest first.
+type SlidingWindow[T any] struct {
+	size int
+	data []T
+}
+
+func NewSlidingWindow[T any](size int) *SlidingWindow[T] {
+	return &SlidingWindow[T]{
+		size: max(size, 1),
+		data: make([]T, 0, max(size, 1)),
+	}
+}
+
+// Push adds a value, dropping the oldest one if the window is full.
+func (w *SlidingWindow[T]) Push(value T) {
+	if len(w.data) == w.size {
+		copy(w.data, w.data[1:])
+		w.data = w.data[:len(w.data)-1]
+	}
+	w.data = append(w.data, value)
+}
+
+// All returns a copy of the values in the window.
+func (w *SlidingWindow[T]) All() []T {
+	return w.Last(len(w.data))
+}
+
+// Last returns a copy of the n most recent values, or of all values if there are fewer.
+func (w *SlidingWindow[T]) Last(n int) []T {
+	n = min(max(n, 0), len(w.data))
+	values := make([]T, n)
+	copy(values, w.data[len(w.data)-n:])
+	return values
+}
+
+func (w *SlidingWindow[T]) Len() int {
+	return len(w.data)
+}
+
+func (w *SlidingWindow[T]) Full() bool {
+	return len(w.data) == w.size
+}
+
+// Map applies fn to every value in the window, oldest first. Go methods cannot declare
+// type parameters, so this is a function rather than a method of SlidingWindow.
+func Map[T, U any](w *SlidingWindow[T], fn func(T) U) []U {
+	values := make([]U, len(w.data))
+	for i, v := range w.data {
+		values[i] = fn(v)
+	}
+	return values
+}
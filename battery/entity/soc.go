@@ -0,0 +1,13 @@
+package entity
+
+// Well-known state of charge thresholds, in percent.
+const (
+	// SoCFull is a fully charged battery; SoC readings are scaled against it.
+	SoCFull = 100.0
+	// SoCLow is the level below which the battery should be kept for self-consumption rather than discharged to the grid.
+	SoCLow = 20.0
+	// SoCCritical is the level at which the BMS may cut off discharge to protect the cells.
+	SoCCritical = 10.0
+	// SoCEmpty is a fully discharged battery.
+	SoCEmpty = 0.0
+)
@@ -0,0 +1,61 @@
+package entity
+
+type SystemStatusOption func(*SystemStatus)
+
+// NewSystemStatus builds a status reading for simulators and fixtures. Unset fields keep their zero values,
+// except that the system is reported as installed and in automatic operating mode.
+func NewSystemStatus(opts ...SystemStatusOption) SystemStatus {
+	s := SystemStatus{
+		IsSystemInstalled: 1,
+		OperatingMode:     string(Automatic),
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// WithSoC sets both the relative and the user state of charge, in percent.
+func WithSoC(pct float64) SystemStatusOption {
+	return func(s *SystemStatus) {
+		s.RSOC = pct
+		s.USOC = pct
+	}
+}
+
+// WithCapacity sets the remaining capacity in Wh.
+func WithCapacity(wh float64) SystemStatusOption {
+	return func(s *SystemStatus) {
+		s.RemainingCapacityWh = wh
+	}
+}
+
+// WithStatus sets the charge and discharge flags the battery status is derived from.
+func WithStatus(status BatteryStatus) SystemStatusOption {
+	return func(s *SystemStatus) {
+		s.BatteryCharging = status == Charging
+		s.BatteryDischarging = status == Discharging
+	}
+}
+
+// WithPower sets the battery AC power in W, positive when discharging.
+func WithPower(w float64) SystemStatusOption {
+	return func(s *SystemStatus) {
+		s.PacTotalW = w
+	}
+}
+
+// WithConsumption sets the household consumption in W.
+func WithConsumption(w float64) SystemStatusOption {
+	return func(s *SystemStatus) {
+		s.ConsumptionW = w
+	}
+}
+
+// WithGrid sets the grid frequency in Hz and voltage in V.
+func WithGrid(frequency, voltage float64) SystemStatusOption {
+	return func(s *SystemStatus) {
+		s.Fac = frequency
+		s.Uac = voltage
+	}
+}
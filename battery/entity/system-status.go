@@ -3,8 +3,13 @@ package entity
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"math"
 )
 
+// PowerBalanceTolerance is the largest power balance, in W, accepted as measurement noise.
+const PowerBalanceTolerance = 50.0
+
 type SystemStatus struct {
 	ApparentOutput            float64     `json:"Apparent_output"`
 	BackupBuffer              string      `json:"BackupBuffer"`
@@ -36,6 +41,8 @@ type SystemStatus struct {
 	Ubat                      float64     `json:"Ubat"`
 	DischargeNotAllowed       bool        `json:"dischargeNotAllowed"`
 	GeneratorAutostart        bool        `json:"generator_autostart"`
+	// Stale marks a last known reading returned while the client has no connection to the BMS.
+	Stale bool `json:"-"`
 }
 
 func ParseSystemStatus(body []byte) (*SystemStatus, error) {
@@ -46,3 +53,112 @@ func ParseSystemStatus(body []byte) (*SystemStatus, error) {
 	}
 	return &status, nil
 }
+
+// BatteryStatus derives the battery status from the charge and discharge flags;
+// the status API does not report fault, calibration, balancing or standby states.
+func (s *SystemStatus) BatteryStatus() BatteryStatus {
+	switch {
+	case s.BatteryDischarging:
+		return Discharging
+	case s.BatteryCharging:
+		return Charging
+	default:
+		return Idle
+	}
+}
+
+// DischargeActive tells whether the inverter reports that the battery is discharging.
+func (s *SystemStatus) DischargeActive() bool {
+	return s.BatteryDischarging
+}
+
+// PowerBalance returns the difference between the power sources and sinks in W, which is zero for
+// self-consistent readings: production plus battery output (positive AC power means discharging)
+// must equal consumption plus grid feed-in (negative when importing from the grid).
+func (s *SystemStatus) PowerBalance() float64 {
+	return s.ProductionW + s.PacTotalW - s.ConsumptionW - s.GridFeedInW
+}
+
+// Validate reports readings whose power balance is beyond PowerBalanceTolerance,
+// which points to a firmware bug in the BMS rather than to a real power flow.
+func (s *SystemStatus) Validate() error {
+	if balance := s.PowerBalance(); math.Abs(balance) > PowerBalanceTolerance {
+		return fmt.Errorf("inconsistent power readings: balance %.0f W", balance)
+	}
+	return nil
+}
+
+// LogValue implements slog.LogValuer, so a status logged with slog.Any is written as a group
+// with one attribute per field, named as in the battery API.
+func (s SystemStatus) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Float64("Apparent_output", s.ApparentOutput),
+		slog.String("BackupBuffer", s.BackupBuffer),
+		slog.Bool("BatteryCharging", s.BatteryCharging),
+		slog.Bool("BatteryDischarging", s.BatteryDischarging),
+		slog.Float64("Consumption_Avg", s.ConsumptionAvg),
+		slog.Float64("Consumption_W", s.ConsumptionW),
+		slog.Float64("Fac", s.Fac),
+		slog.Bool("FlowConsumptionBattery", s.FlowConsumptionBattery),
+		slog.Bool("FlowConsumptionGrid", s.FlowConsumptionGrid),
+		slog.Bool("FlowConsumptionProduction", s.FlowConsumptionProduction),
+		slog.Bool("FlowGridBattery", s.FlowGridBattery),
+		slog.Bool("FlowProductionBattery", s.FlowProductionBattery),
+		slog.Bool("FlowProductionGrid", s.FlowProductionGrid),
+		slog.Float64("GridFeedIn_W", s.GridFeedInW),
+		slog.Float64("IsSystemInstalled", s.IsSystemInstalled),
+		slog.String("OperatingMode", s.OperatingMode),
+		slog.Float64("Pac_total_W", s.PacTotalW),
+		slog.Float64("Production_W", s.ProductionW),
+		slog.Float64("RSOC", s.RSOC),
+		slog.Float64("RemainingCapacity_Wh", s.RemainingCapacityWh),
+		slog.Float64("Sac1", s.Sac1),
+		slog.Any("Sac2", s.Sac2),
+		slog.Any("Sac3", s.Sac3),
+		slog.String("SystemStatus", s.SystemStatus),
+		slog.String("Timestamp", s.Timestamp),
+		slog.Float64("USOC", s.USOC),
+		slog.Float64("Uac", s.Uac),
+		slog.Float64("Ubat", s.Ubat),
+		slog.Bool("dischargeNotAllowed", s.DischargeNotAllowed),
+		slog.Bool("generator_autostart", s.GeneratorAutostart),
+	)
+}
+
+// String returns a one-line summary in a fixed format, which command line tools may parse:
+//
+//	Battery[SoC=87.2% Cap=8.4kWh Pac=-1200W Status=charging]
+//
+// SoC has one decimal, capacity is in kWh with one decimal, Pac is in whole watts (negative while charging)
+// and Status is the name of the derived BatteryStatus. The status API has no temperature reading.
+func (s SystemStatus) String() string {
+	return fmt.Sprintf("Battery[SoC=%.1f%% Cap=%.1fkWh Pac=%.0fW Status=%s]",
+		s.RSOC, s.RemainingCapacityWh/1000, s.PacTotalW, s.BatteryStatus())
+}
+
+// Clone returns a deep copy of the status, including the untyped Sac2 and Sac3 values.
+func (s SystemStatus) Clone() SystemStatus {
+	s.Sac2 = cloneValue(s.Sac2)
+	s.Sac3 = cloneValue(s.Sac3)
+	return s
+}
+
+// cloneValue copies the maps and slices that JSON decoding produces for untyped fields.
+func cloneValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[k] = cloneValue(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = cloneValue(item)
+		}
+		return list
+	default:
+		return value
+	}
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type TimePoint[T comparable] struct {
+	Time  time.Time `json:"t"`
+	Value T         `json:"v"`
+}
+
+// TimeSeries is a sequence of time-stamped values, kept ordered by time.
+type TimeSeries[T comparable] struct {
+	Points []TimePoint[T] `json:"points"`
+}
+
+// Add inserts a value at time t; a value at the same time as an existing point is placed after it.
+func (ts *TimeSeries[T]) Add(t time.Time, v T) {
+	i := sort.Search(len(ts.Points), func(i int) bool {
+		return ts.Points[i].Time.After(t)
+	})
+	ts.Points = append(ts.Points, TimePoint[T]{})
+	copy(ts.Points[i+1:], ts.Points[i:])
+	ts.Points[i] = TimePoint[T]{Time: t, Value: v}
+}
+
+// Between returns the points with a time in [start, end). The result shares memory with the series.
+func (ts *TimeSeries[T]) Between(start, end time.Time) []TimePoint[T] {
+	from := sort.Search(len(ts.Points), func(i int) bool {
+		return !ts.Points[i].Time.Before(start)
+	})
+	to := sort.Search(len(ts.Points), func(i int) bool {
+		return !ts.Points[i].Time.Before(end)
+	})
+	if from >= to {
+		return nil
+	}
+	return ts.Points[from:to]
+}
+
+// Resample groups the points into intervals aligned to the zero time (whole hours for an hourly interval)
+// and reduces the values of each interval with aggregate. Empty intervals are skipped.
+func (ts *TimeSeries[T]) Resample(interval time.Duration, aggregate func([]T) T) TimeSeries[T] {
+	var result TimeSeries[T]
+	if interval <= 0 {
+		return result
+	}
+	var values []T
+	var bucket time.Time
+	for _, p := range ts.Points {
+		t := p.Time.Truncate(interval)
+		if len(values) > 0 && !t.Equal(bucket) {
+			result.Points = append(result.Points, TimePoint[T]{Time: bucket, Value: aggregate(values)})
+			values = nil
+		}
+		bucket = t
+		values = append(values, p.Value)
+	}
+	if len(values) > 0 {
+		result.Points = append(result.Points, TimePoint[T]{Time: bucket, Value: aggregate(values)})
+	}
+	return result
+}
+
+// Interpolate returns the value at time t, linearly interpolated between the nearest points before and after it.
+// A point exactly at t is returned as is. Outside the range of the series, including an empty series,
+// the result is math.NaN(); values are never extrapolated.
+func Interpolate(ts TimeSeries[float64], t time.Time) float64 {
+	i := sort.Search(len(ts.Points), func(i int) bool {
+		return !ts.Points[i].Time.Before(t)
+	})
+	if i == len(ts.Points) {
+		return math.NaN()
+	}
+	next := ts.Points[i]
+	if next.Time.Equal(t) {
+		return next.Value
+	}
+	if i == 0 {
+		return math.NaN()
+	}
+	prev := ts.Points[i-1]
+	ratio := float64(t.Sub(prev.Time)) / float64(next.Time.Sub(prev.Time))
+	return prev.Value + (next.Value-prev.Value)*ratio
+}
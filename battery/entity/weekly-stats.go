@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// WeeklyStats aggregates the discharge sessions of one week.
+type WeeklyStats struct {
+	WeekStart               time.Time `json:"weekStart"`
+	TotalEnergyDischargedWh float64   `json:"totalEnergyDischargedWh"`
+	TotalCostSaved          float64   `json:"totalCostSaved"`
+	TotalCO2SavedKg         float64   `json:"totalCO2SavedKg"`
+	AvgEndSoC               float64   `json:"avgEndSoC"`
+	SessionsCompleted       int       `json:"sessionsCompleted"`
+}
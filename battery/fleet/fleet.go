@@ -0,0 +1,146 @@
+package fleet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gok-pi/battery/api"
+	"gok-pi/battery/discharger"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/metrics/observers"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// stopTimeout is how long Deregister waits for a worker to finish its Run loop.
+const stopTimeout = 30 * time.Second
+
+var (
+	ErrExists   = errors.New("battery already registered")
+	ErrNotFound = errors.New("battery not registered")
+	ErrTimeout  = errors.New("timed out waiting for worker to stop")
+)
+
+// Registry exposes the workers of the fleet, e.g. the API server.
+type Registry interface {
+	Register(battery api.Battery)
+	Deregister(name string)
+}
+
+type worker struct {
+	discharge *discharger.Discharge
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// Fleet runs the discharge workers of all batteries. Batteries can be added and removed
+// while the daemon is running, e.g. from API handlers.
+type Fleet struct {
+	ctx      context.Context
+	workers  map[string]*worker
+	registry Registry
+	wg       sync.WaitGroup
+	mutex    sync.RWMutex
+	log      *slog.Logger
+}
+
+// New creates a fleet whose workers run until ctx is cancelled or they are deregistered.
+// Workers are added to and removed from registry along with the fleet.
+func New(ctx context.Context, registry Registry, log *slog.Logger) *Fleet {
+	return &Fleet{
+		ctx:      ctx,
+		workers:  make(map[string]*worker),
+		registry: registry,
+		log:      log.With(sl.Module("battery.fleet")),
+	}
+}
+
+// Register starts running d under name.
+func (f *Fleet) Register(name string, d *discharger.Discharge) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, ok := f.workers[name]; ok {
+		return fmt.Errorf("%w: %s", ErrExists, name)
+	}
+
+	ctx, cancel := context.WithCancel(f.ctx)
+	w := &worker{
+		discharge: d,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	f.workers[name] = w
+	f.registry.Register(d)
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer close(w.done)
+		log := f.log.With(slog.String("battery", name))
+		if err := d.Run(ctx); err != nil {
+			log.With(sl.Err(err)).Error("running discharge worker")
+		}
+		log.Info("discharge worker stopped")
+	}()
+	return nil
+}
+
+// Deregister removes the worker of name from the registry, stops it and waits for it to return.
+// Its metrics are removed once it has returned, so a worker that is still publishing after the
+// timeout does not recreate them.
+func (f *Fleet) Deregister(name string) error {
+	f.mutex.Lock()
+	w, ok := f.workers[name]
+	if ok {
+		delete(f.workers, name)
+	}
+	f.mutex.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	f.registry.Deregister(name)
+	w.cancel()
+
+	timer := time.NewTimer(stopTimeout)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+		observers.Unregister(name)
+		return nil
+	case <-timer.C:
+		go func() {
+			<-w.done
+			observers.Unregister(name)
+		}()
+		return fmt.Errorf("%w: %s", ErrTimeout, name)
+	}
+}
+
+// Get returns the worker registered under name.
+func (f *Fleet) Get(name string) (*discharger.Discharge, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	w, ok := f.workers[name]
+	if !ok {
+		return nil, false
+	}
+	return w.discharge, true
+}
+
+// Names returns the names of all registered batteries.
+func (f *Fleet) Names() []string {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	names := make([]string, 0, len(f.workers))
+	for name := range f.workers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Wait blocks until all workers have returned.
+func (f *Fleet) Wait() {
+	f.wg.Wait()
+}
@@ -0,0 +1,73 @@
+package fleet_test
+
+import (
+	"context"
+	"gok-pi/battery/api"
+	"gok-pi/battery/client/mock"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/battery/fleet"
+	"gok-pi/internal/lib/testutil"
+	"sync"
+	"testing"
+)
+
+// registry records the batteries registered by the fleet.
+type registry struct {
+	batteries map[string]api.Battery
+	mutex     sync.Mutex
+}
+
+func (r *registry) Register(battery api.Battery) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.batteries[battery.Name()] = battery
+}
+
+func (r *registry) Deregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.batteries, name)
+}
+
+func (r *registry) has(name string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	_, ok := r.batteries[name]
+	return ok
+}
+
+func TestDeregister(t *testing.T) {
+	log := testutil.NewTestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reg := &registry{batteries: make(map[string]api.Battery)}
+	workers := fleet.New(ctx, reg, log)
+
+	client := mock.New()
+	client.SetStatusSequence([]entity.SystemStatus{{OperatingMode: string(entity.Automatic), RSOC: 80}})
+	worker, err := discharger.New("home", client, log)
+	if err != nil {
+		t.Fatalf("creating worker: %v", err)
+	}
+	if err = workers.Register("home", worker); err != nil {
+		t.Fatalf("registering worker: %v", err)
+	}
+	if !reg.has("home") {
+		t.Error("registered worker missing from the registry")
+	}
+	testutil.AssertErrorIs(t, workers.Register("home", worker), fleet.ErrExists)
+
+	if err = workers.Deregister("home"); err != nil {
+		t.Fatalf("deregistering worker: %v", err)
+	}
+	if reg.has("home") {
+		t.Error("deregistered worker still in the registry")
+	}
+	if _, ok := workers.Get("home"); ok {
+		t.Error("deregistered worker still in the fleet")
+	}
+	testutil.AssertErrorIs(t, workers.Deregister("home"), fleet.ErrNotFound)
+	workers.Wait()
+}
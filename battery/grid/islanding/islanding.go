@@ -0,0 +1,78 @@
+package islanding
+
+import (
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"sync"
+)
+
+// IslandDetector watches grid frequency and voltage readings. A connected grid holds both
+// steady, while an inverter running in island mode has no reference and lets them wander,
+// so a variance above the limits over the sample window is treated as islanding.
+type IslandDetector struct {
+	frequencyVariance float64
+	voltageVariance   float64
+	readings          *entity.SlidingWindow[reading]
+	islanded          bool
+	mutex             sync.Mutex
+	log               *slog.Logger
+}
+
+// New creates a detector over the last window readings, with variance limits in Hz² and V².
+func New(window int, frequencyVariance, voltageVariance float64, log *slog.Logger) *IslandDetector {
+	return &IslandDetector{
+		readings:          entity.NewSlidingWindow[reading](max(window, 2)),
+		frequencyVariance: frequencyVariance,
+		voltageVariance:   voltageVariance,
+		log:               log.With(sl.Module("grid.islanding")),
+	}
+}
+
+// Observe adds a reading of grid frequency (Hz) and voltage (V).
+func (d *IslandDetector) Observe(frequency, voltage float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.readings.Push(reading{frequency: frequency, voltage: voltage})
+	if !d.readings.Full() {
+		return
+	}
+
+	fv := variance(entity.Map(d.readings, func(r reading) float64 { return r.frequency }))
+	vv := variance(entity.Map(d.readings, func(r reading) float64 { return r.voltage }))
+	islanded := fv > d.frequencyVariance || vv > d.voltageVariance
+	if islanded != d.islanded {
+		d.log.With(
+			slog.Bool("islanded", islanded),
+			slog.Float64("frequency_variance", fv),
+			slog.Float64("voltage_variance", vv),
+		).Warn("grid connection state changed")
+	}
+	d.islanded = islanded
+}
+
+// IsIslanded reports whether the last full window of readings indicates island operation.
+func (d *IslandDetector) IsIslanded() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.islanded
+}
+
+type reading struct {
+	frequency float64
+	voltage   float64
+}
+
+func variance(values []float64) float64 {
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(values))
+}
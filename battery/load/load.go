@@ -0,0 +1,106 @@
+package load
+
+import (
+	"errors"
+	"fmt"
+	"gok-pi/battery/tariff"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// startStep is the granularity of candidate start times
+	startStep = 15 * time.Minute
+	// sampleStep is the resolution at which prices are sampled over an appliance run
+	sampleStep = 15 * time.Minute
+)
+
+var ErrNoWindow = errors.New("appliance run does not fit before the deadline")
+
+// Appliance is a scheduled run of a household appliance.
+type Appliance struct {
+	Name     string        `json:"name"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Deadline time.Time     `json:"deadline"`
+}
+
+// ApplianceScheduler finds the cheapest time to run appliances and keeps the last schedule per appliance.
+type ApplianceScheduler struct {
+	scheduled map[string]Appliance
+	mutex     sync.Mutex
+	log       *slog.Logger
+}
+
+func New(log *slog.Logger) *ApplianceScheduler {
+	return &ApplianceScheduler{
+		scheduled: make(map[string]Appliance),
+		log:       log.With(sl.Module("load.scheduler")),
+	}
+}
+
+// ScheduleAppliance returns the start time, on a 15-minute grid from now, at which a run of
+// the given duration has the lowest average price and still finishes by the deadline.
+func (s *ApplianceScheduler) ScheduleAppliance(name string, duration time.Duration, deadline time.Time, tariff tariff.TariffSource) (time.Time, error) {
+	if duration <= 0 {
+		return time.Time{}, fmt.Errorf("invalid duration: %s", duration)
+	}
+	first := time.Now().Truncate(startStep).Add(startStep)
+	last := deadline.Add(-duration)
+	if last.Before(first) {
+		return time.Time{}, ErrNoWindow
+	}
+
+	best := time.Time{}
+	bestCost := math.Inf(1)
+	for start := first; !start.After(last); start = start.Add(startStep) {
+		cost, err := runCost(start, duration, tariff)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("price at %s: %w", start.Format(time.RFC3339), err)
+		}
+		if cost < bestCost {
+			best, bestCost = start, cost
+		}
+	}
+
+	s.mutex.Lock()
+	s.scheduled[name] = Appliance{Name: name, Start: best, Duration: duration, Deadline: deadline}
+	s.mutex.Unlock()
+
+	s.log.With(
+		slog.String("appliance", name),
+		slog.Time("start", best),
+		slog.Duration("duration", duration),
+		slog.Float64("avg_price", bestCost),
+	).Info("appliance scheduled")
+	return best, nil
+}
+
+// Scheduled returns the latest schedule of every appliance.
+func (s *ApplianceScheduler) Scheduled() []Appliance {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	appliances := make([]Appliance, 0, len(s.scheduled))
+	for _, a := range s.scheduled {
+		appliances = append(appliances, a)
+	}
+	return appliances
+}
+
+// runCost returns the average price over the run, sampled at the start of every sample step.
+func runCost(start time.Time, duration time.Duration, tariff tariff.TariffSource) (float64, error) {
+	var sum float64
+	var count int
+	for t := start; t.Before(start.Add(duration)); t = t.Add(sampleStep) {
+		price, err := tariff.PriceAt(t)
+		if err != nil {
+			return 0, err
+		}
+		sum += price
+		count++
+	}
+	return sum / float64(count), nil
+}
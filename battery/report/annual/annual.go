@@ -0,0 +1,141 @@
+package annual
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"gok-pi/battery/entity"
+	"gok-pi/battery/storage"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	worstSessions = 5
+	// maxSnapshotGap limits integration of power readings across outages of the daemon
+	maxSnapshotGap = 5 * time.Minute
+)
+
+// AnnualReport generates yearly energy statistics from the event store.
+type AnnualReport struct {
+	Year       int
+	OutputPath string
+}
+
+type Report struct {
+	Year               int                     `json:"year"`
+	GeneratedAt        time.Time               `json:"generated_at"`
+	Sessions           int                     `json:"sessions"`
+	EnergyDischargedWh float64                 `json:"energy_discharged_wh"`
+	EnergyChargedWh    float64                 `json:"energy_charged_wh"`
+	CostSaved          float64                 `json:"cost_saved"`
+	CO2SavedKg         float64                 `json:"co2_saved_kg"`
+	HourlySoC          [24]float64             `json:"hourly_soc"`
+	WorstSessions      []entity.SessionSummary `json:"worst_sessions"`
+}
+
+// Generate computes the report for the year and writes it as JSON to OutputPath.
+// Snapshots are read one day at a time, since a year of 10 second readings does not fit
+// in the memory of a small device.
+func (a *AnnualReport) Generate(ctx context.Context, store storage.EventStore) error {
+	from := time.Date(a.Year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+
+	sessions, err := store.QuerySessions(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("querying sessions: %w", err)
+	}
+	stats := newSnapshotStats()
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		snapshots, err := store.QuerySnapshots(ctx, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return fmt.Errorf("querying snapshots of %s: %w", day.Format(time.DateOnly), err)
+		}
+		for _, s := range snapshots {
+			stats.add(s)
+		}
+	}
+
+	report := Report{
+		Year:        a.Year,
+		GeneratedAt: time.Now(),
+		Sessions:    len(sessions),
+	}
+	for _, s := range sessions {
+		report.EnergyDischargedWh += s.EnergyWh
+		report.CostSaved += s.CostSaved
+		report.CO2SavedKg += s.CO2SavedKg
+	}
+	report.EnergyChargedWh = stats.chargedWh
+	report.HourlySoC = stats.hourlySoC()
+	report.WorstSessions = worst(sessions)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling report: %w", err)
+	}
+	if err = os.WriteFile(a.OutputPath, data, 0644); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
+
+// snapshotStats accumulates the snapshot statistics of the report, fed in time order.
+type snapshotStats struct {
+	previous  map[string]entity.BatterySnapshot
+	chargedWh float64
+	socSum    [24]float64
+	socCount  [24]int
+}
+
+func newSnapshotStats() *snapshotStats {
+	return &snapshotStats{previous: make(map[string]entity.BatterySnapshot)}
+}
+
+// add integrates negative AC power since the previous snapshot of the battery, and adds the state
+// of charge to the average of its hour of the day.
+func (st *snapshotStats) add(s entity.BatterySnapshot) {
+	h := s.Time.Hour()
+	st.socSum[h] += s.SoC
+	st.socCount[h]++
+
+	p, ok := st.previous[s.Battery]
+	st.previous[s.Battery] = s
+	if !ok || p.PacW >= 0 {
+		return
+	}
+	gap := s.Time.Sub(p.Time)
+	if gap <= 0 || gap > maxSnapshotGap {
+		return
+	}
+	st.chargedWh += -p.PacW * gap.Hours()
+}
+
+// hourlySoC returns the average state of charge for each hour of the day.
+func (st *snapshotStats) hourlySoC() [24]float64 {
+	var avg [24]float64
+	for h := range avg {
+		if st.socCount[h] > 0 {
+			avg[h] = st.socSum[h] / float64(st.socCount[h])
+		}
+	}
+	return avg
+}
+
+// worst returns the sessions that ended furthest above their SoC limit, i.e. failed to use the battery.
+func worst(sessions []entity.SessionSummary) []entity.SessionSummary {
+	var failed []entity.SessionSummary
+	for _, s := range sessions {
+		if s.EndSoC > s.SocLimit {
+			failed = append(failed, s)
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].EndSoC-failed[i].SocLimit > failed[j].EndSoC-failed[j].SocLimit
+	})
+	if len(failed) > worstSessions {
+		failed = failed[:worstSessions]
+	}
+	return failed
+}
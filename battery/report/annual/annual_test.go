@@ -0,0 +1,84 @@
+package annual
+
+import (
+	"context"
+	"encoding/json"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/testutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// dayStore serves snapshots from memory and fails queries spanning more than a day.
+type dayStore struct {
+	t         *testing.T
+	snapshots []entity.BatterySnapshot
+	queries   int
+}
+
+func (s *dayStore) SaveSnapshot(context.Context, entity.BatterySnapshot) error { return nil }
+
+func (s *dayStore) SaveSession(context.Context, entity.SessionSummary) error { return nil }
+
+func (s *dayStore) QuerySnapshots(_ context.Context, from, to time.Time) ([]entity.BatterySnapshot, error) {
+	s.queries++
+	if to.Sub(from) > 25*time.Hour {
+		s.t.Errorf("snapshot query from %s to %s spans more than a day", from, to)
+	}
+	var result []entity.BatterySnapshot
+	for _, snapshot := range s.snapshots {
+		if !snapshot.Time.Before(from) && snapshot.Time.Before(to) {
+			result = append(result, snapshot)
+		}
+	}
+	return result, nil
+}
+
+func (s *dayStore) QueryBatterySnapshots(context.Context, string, time.Time, time.Time) ([]entity.BatterySnapshot, error) {
+	return nil, nil
+}
+
+func (s *dayStore) QuerySessions(context.Context, time.Time, time.Time) ([]entity.SessionSummary, error) {
+	return nil, nil
+}
+
+func TestGenerateAcrossMidnight(t *testing.T) {
+	midnight := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.Local)
+	store := &dayStore{t: t}
+	// charging at 1200 W from 23:59 to 00:01 spans two daily queries
+	for i := -1; i <= 1; i++ {
+		store.snapshots = append(store.snapshots, entity.BatterySnapshot{
+			Battery: "home",
+			Time:    midnight.Add(time.Duration(i) * time.Minute),
+			SoC:     float64(50 + i),
+			PacW:    -1200,
+		})
+	}
+
+	path := filepath.Join(testutil.MustMkdirTemp(t), "annual.json")
+	report := AnnualReport{Year: 2024, OutputPath: path}
+	if err := report.Generate(context.Background(), store); err != nil {
+		t.Fatalf("generating report: %v", err)
+	}
+	if store.queries != 366 {
+		t.Errorf("%d snapshot queries, want one per day of 2024", store.queries)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	var result Report
+	if err = json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("decoding report: %v", err)
+	}
+	if math.Abs(result.EnergyChargedWh-40) > 1e-6 {
+		t.Errorf("charged %v Wh, want 40 Wh", result.EnergyChargedWh)
+	}
+	if result.HourlySoC[23] != 49 || result.HourlySoC[0] != 50.5 {
+		t.Errorf("hourly SoC at 23h %v, at 0h %v, want 49 and 50.5", result.HourlySoC[23], result.HourlySoC[0])
+	}
+}
@@ -0,0 +1,175 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"gok-pi/internal/lib/timer"
+	"io"
+	"strings"
+	"time"
+)
+
+// EventSummary is the summary, compared case-insensitively, of the calendar events that are discharge windows.
+const EventSummary = "discharge"
+
+const (
+	layoutUTC   = "20060102T150405Z"
+	layoutLocal = "20060102T150405"
+	layoutDate  = "20060102"
+)
+
+// Event is a VEVENT record of a calendar, lasting from DTSTART to DTEND.
+type Event struct {
+	Summary string
+	Window  timer.TimeRange
+}
+
+type event struct {
+	summary string
+	start   string
+	end     string
+}
+
+// ParseICS reads an iCalendar (VCALENDAR) stream and returns the windows of the VEVENT records summarised
+// "discharge", from DTSTART to DTEND. Times in UTC, with a TZID parameter, floating (in the local zone)
+// and whole dates are supported; recurrence rules are not expanded, so every window must be its own event.
+func ParseICS(r io.Reader) ([]timer.TimeRange, error) {
+	events, err := parse(r, func(summary string) bool {
+		return strings.EqualFold(strings.TrimSpace(summary), EventSummary)
+	})
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]timer.TimeRange, 0, len(events))
+	for _, e := range events {
+		ranges = append(ranges, e.Window)
+	}
+	return ranges, nil
+}
+
+// ParseEvents reads an iCalendar stream and returns all its VEVENT records, e.g. the days of a public
+// holiday calendar. A whole-day event without DTEND lasts one day.
+func ParseEvents(r io.Reader) ([]Event, error) {
+	return parse(r, func(string) bool { return true })
+}
+
+// parse returns the events whose summary is accepted by keep; other events are not validated.
+func parse(r io.Reader, keep func(summary string) bool) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading calendar: %w", err)
+	}
+	var events []Event
+	var current *event
+	for _, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &event{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("unexpected END:VEVENT")
+			}
+			if keep(current.summary) {
+				window, err := current.timeRange()
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, Event{Summary: strings.TrimSpace(current.summary), Window: window})
+			}
+			current = nil
+		case current == nil:
+			// calendar properties and other components are ignored
+		case name == "SUMMARY":
+			current.summary = value
+		case name == "DTSTART":
+			current.start = params + ":" + value
+		case name == "DTEND":
+			current.end = params + ":" + value
+		}
+	}
+	return events, nil
+}
+
+func (e *event) timeRange() (timer.TimeRange, error) {
+	if e.start == "" {
+		return timer.TimeRange{}, fmt.Errorf("event without DTSTART: %s", e.summary)
+	}
+	start, err := parseDateTime(e.start)
+	if err != nil {
+		return timer.TimeRange{}, fmt.Errorf("parsing DTSTART: %w", err)
+	}
+	if e.end == "" {
+		if !isDate(e.start) {
+			return timer.TimeRange{}, fmt.Errorf("event without DTEND: %s", e.summary)
+		}
+		return timer.TimeRange{Start: start, Stop: start.AddDate(0, 0, 1)}, nil
+	}
+	end, err := parseDateTime(e.end)
+	if err != nil {
+		return timer.TimeRange{}, fmt.Errorf("parsing DTEND: %w", err)
+	}
+	window := timer.TimeRange{Start: start, Stop: end}
+	if window.Duration() <= 0 {
+		return timer.TimeRange{}, fmt.Errorf("event ends before it starts: %s", e.start)
+	}
+	return window, nil
+}
+
+// parseDateTime parses a property value prefixed with its parameters, as "params:value".
+func parseDateTime(property string) (time.Time, error) {
+	params, value, _ := strings.Cut(property, ":")
+	loc := time.Local
+	for _, param := range strings.Split(params, ";") {
+		key, v, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "TZID") {
+			tz, err := time.LoadLocation(strings.Trim(v, `"`))
+			if err != nil {
+				return time.Time{}, err
+			}
+			loc = tz
+		}
+	}
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse(layoutUTC, value)
+	case len(value) == len(layoutDate):
+		return time.ParseInLocation(layoutDate, value, loc)
+	default:
+		return time.ParseInLocation(layoutLocal, value, loc)
+	}
+}
+
+// isDate tells whether a property value prefixed with its parameters is a whole date.
+func isDate(property string) bool {
+	_, value, _ := strings.Cut(property, ":")
+	return len(value) == len(layoutDate)
+}
+
+// splitLine splits a content line into its upper-cased name, its parameters and its value.
+func splitLine(line string) (name, params, value string, ok bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", "", false
+	}
+	name, params, _ = strings.Cut(head, ";")
+	return strings.ToUpper(name), params, value, true
+}
+
+// unfold joins content lines continued on the next line by a leading space or tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,137 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gok-pi/battery/scheduler"
+	"gok-pi/internal/lib/sl"
+	"gok-pi/internal/lib/timer"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+var httpClient = &http.Client{}
+
+// Session is a discharge window computed by the schedule server, with the SoC to discharge down to.
+// A LimitPct of zero keeps the SoC limit of the worker.
+type Session struct {
+	Start    time.Time `json:"start"`
+	Stop     time.Time `json:"stop"`
+	LimitPct float64   `json:"limit_pct"`
+}
+
+// RemoteSchedule polls a schedule server, e.g. an energy management platform, for a JSON array of sessions
+// and updates the scheduler with their windows and SoC limits. When the server cannot be reached or returns an invalid
+// schedule, the last known schedule is kept.
+type RemoteSchedule struct {
+	url       string
+	token     string
+	interval  time.Duration
+	scheduler *scheduler.Scheduler
+	sessions  []Session
+	mutex     sync.Mutex
+	log       *slog.Logger
+}
+
+// New returns a schedule polling url every interval; a non-empty token is sent as a Bearer token.
+func New(url, token string, interval time.Duration, scheduler *scheduler.Scheduler, log *slog.Logger) *RemoteSchedule {
+	log.With(
+		slog.String("url", url),
+		sl.Secret("token", token),
+		slog.Duration("interval", interval),
+	).Info("creating remote schedule")
+	return &RemoteSchedule{
+		url:       url,
+		token:     token,
+		interval:  interval,
+		scheduler: scheduler,
+		log:       log.With(sl.Module("scheduler.remote")),
+	}
+}
+
+// Run polls the schedule server right away and then every interval, until the context is cancelled.
+func (s *RemoteSchedule) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sessions returns a copy of the last known schedule.
+func (s *RemoteSchedule) Sessions() []Session {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return slices.Clone(s.sessions)
+}
+
+func (s *RemoteSchedule) poll(ctx context.Context) {
+	sessions, err := s.fetch(ctx)
+	if err != nil {
+		s.log.With(sl.Err(err)).Warn("fetching schedule; keeping the last known schedule")
+		return
+	}
+	windows := make([]scheduler.Window, 0, len(sessions))
+	for _, session := range sessions {
+		windows = append(windows, scheduler.Window{
+			TimeRange: timer.TimeRange{Start: session.Start, Stop: session.Stop},
+			SocLimit:  session.LimitPct,
+		})
+	}
+	s.mutex.Lock()
+	s.sessions = sessions
+	s.mutex.Unlock()
+	s.scheduler.SetLimitedWindows(windows)
+	s.log.With(slog.Int("sessions", len(sessions))).Debug("schedule updated")
+}
+
+func (s *RemoteSchedule) fetch(ctx context.Context) ([]Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timeout")
+		}
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	var sessions []Session
+	if err = json.NewDecoder(resp.Body).Decode(&sessions); err != nil {
+		return nil, fmt.Errorf("decoding schedule: %w", err)
+	}
+	for _, session := range sessions {
+		if !session.Stop.After(session.Start) {
+			return nil, fmt.Errorf("session stops before it starts: %s", session.Start.Format(time.RFC3339))
+		}
+		if session.LimitPct < 0 || session.LimitPct > 100 {
+			return nil, fmt.Errorf("session limit out of range: %.1f%%", session.LimitPct)
+		}
+	}
+	return sessions, nil
+}
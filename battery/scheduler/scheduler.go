@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"gok-pi/internal/lib/timer"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Window is a scheduled discharge window. A SocLimit of zero keeps the SoC limit of the worker.
+type Window struct {
+	timer.TimeRange
+	SocLimit float64
+}
+
+// Scheduler holds a list of discharge windows with absolute dates, e.g. imported from a calendar.
+// It is safe for concurrent use, so the windows can be replaced while the schedule is read.
+type Scheduler struct {
+	windows []Window
+	mutex   sync.RWMutex
+}
+
+// New returns a scheduler for the given windows; the list is copied and sorted by start time.
+func New(windows []timer.TimeRange) *Scheduler {
+	s := &Scheduler{}
+	s.SetWindows(windows)
+	return s
+}
+
+// SetWindows replaces the scheduled windows, keeping the SoC limit of the worker for all of them.
+func (s *Scheduler) SetWindows(windows []timer.TimeRange) {
+	limited := make([]Window, 0, len(windows))
+	for _, w := range windows {
+		limited = append(limited, Window{TimeRange: w})
+	}
+	s.SetLimitedWindows(limited)
+}
+
+// SetLimitedWindows replaces the scheduled windows along with their SoC limits; the list is copied
+// and sorted by start time.
+func (s *Scheduler) SetLimitedWindows(windows []Window) {
+	sorted := slices.Clone(windows)
+	slices.SortFunc(sorted, func(a, b Window) int {
+		return a.Start.Compare(b.Start)
+	})
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.windows = sorted
+}
+
+// Active returns the window containing t, if any.
+func (s *Scheduler) Active(t time.Time) (Window, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, w := range s.windows {
+		if w.Contains(t) {
+			return w, true
+		}
+	}
+	return Window{}, false
+}
+
+// Next returns the first window starting after t, if any.
+func (s *Scheduler) Next(t time.Time) (Window, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, w := range s.windows {
+		if w.Start.After(t) {
+			return w, true
+		}
+	}
+	return Window{}, false
+}
+
+// Windows returns a copy of the scheduled windows, sorted by start time.
+func (s *Scheduler) Windows() []Window {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return slices.Clone(s.windows)
+}
@@ -0,0 +1,15 @@
+package scheduler
+
+import "time"
+
+// SeasonalLimitFn returns a SoC limit function for the discharger: summerLimit from April to September,
+// when the next day's solar yield refills the battery, and winterLimit in the other months.
+// The seasons are those of the northern hemisphere; swap the limits for the southern one.
+func SeasonalLimitFn(winterLimit, summerLimit float64) func(time.Time) float64 {
+	return func(t time.Time) float64 {
+		if t.Month() >= time.April && t.Month() <= time.September {
+			return summerLimit
+		}
+		return winterLimit
+	}
+}
@@ -0,0 +1,46 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// zenith of the sun at sunrise and sunset, accounting for atmospheric refraction and the solar disc size
+const zenith = 90.833
+
+// SunriseSunset returns the times of sunrise and sunset on the calendar day of date, at the given
+// latitude and longitude in degrees (north and east positive), in the location of date.
+// It uses the NOAA general solar position equations, accurate to about a minute at mid-latitudes.
+// During polar night both times equal solar noon; during midnight sun they span the whole day.
+func SunriseSunset(lat, lon float64, date time.Time) (sunrise, sunset time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	gamma := 2 * math.Pi / 365 * float64(day.YearDay()-1)
+	eqTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	decl := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	latRad := lat * math.Pi / 180
+	cosHa := math.Cos(zenith*math.Pi/180)/(math.Cos(latRad)*math.Cos(decl)) - math.Tan(latRad)*math.Tan(decl)
+
+	noon := 720 - 4*lon - eqTime
+	var ha float64
+	switch {
+	case cosHa > 1:
+		ha = 0
+	case cosHa < -1:
+		ha = 180
+	default:
+		ha = math.Acos(cosHa) * 180 / math.Pi
+	}
+
+	sunrise = day.Add(minutes(noon - 4*ha)).In(date.Location())
+	sunset = day.Add(minutes(noon + 4*ha)).In(date.Location())
+	return sunrise, sunset
+}
+
+func minutes(m float64) time.Duration {
+	return time.Duration(m * float64(time.Minute))
+}
@@ -0,0 +1,160 @@
+package remoteread
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/encoding/protowire"
+	"math"
+	"time"
+)
+
+// The remote read messages are small, so they are encoded by hand instead of pulling in
+// the generated prompb package with the whole Prometheus module. Field numbers follow
+// prometheus/prompb/remote.proto and types.proto.
+
+const matchEqual = 0
+
+type query struct {
+	name  string
+	label string
+	value string
+}
+
+type sample struct {
+	time  time.Time
+	value float64
+}
+
+type series struct {
+	labels  map[string]string
+	samples []sample
+}
+
+// encodeReadRequest builds a ReadRequest with one Query per item, each matching a metric name
+// and, optionally, one more label.
+func encodeReadRequest(from, to time.Time, queries []query) []byte {
+	var request []byte
+	for _, q := range queries {
+		var msg []byte
+		msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(from.UnixMilli()))
+		msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(to.UnixMilli()))
+		msg = appendMatcher(msg, "__name__", q.name)
+		if q.label != "" {
+			msg = appendMatcher(msg, q.label, q.value)
+		}
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, msg)
+	}
+	return request
+}
+
+func appendMatcher(msg []byte, name, value string) []byte {
+	var m []byte
+	m = protowire.AppendTag(m, 1, protowire.VarintType)
+	m = protowire.AppendVarint(m, matchEqual)
+	m = protowire.AppendTag(m, 2, protowire.BytesType)
+	m = protowire.AppendString(m, name)
+	m = protowire.AppendTag(m, 3, protowire.BytesType)
+	m = protowire.AppendString(m, value)
+	msg = protowire.AppendTag(msg, 3, protowire.BytesType)
+	return protowire.AppendBytes(msg, m)
+}
+
+// decodeReadResponse returns the time series of every QueryResult in the ReadResponse.
+func decodeReadResponse(data []byte) ([][]series, error) {
+	var results [][]series
+	err := forEachField(data, func(num protowire.Number, b []byte, _ uint64) error {
+		if num != 1 {
+			return nil
+		}
+		var result []series
+		err := forEachField(b, func(num protowire.Number, b []byte, _ uint64) error {
+			if num != 1 {
+				return nil
+			}
+			s, err := decodeSeries(b)
+			if err != nil {
+				return err
+			}
+			result = append(result, s)
+			return nil
+		})
+		results = append(results, result)
+		return err
+	})
+	return results, err
+}
+
+func decodeSeries(data []byte) (series, error) {
+	s := series{labels: make(map[string]string)}
+	err := forEachField(data, func(num protowire.Number, b []byte, _ uint64) error {
+		switch num {
+		case 1:
+			var name, value string
+			err := forEachField(b, func(num protowire.Number, b []byte, _ uint64) error {
+				switch num {
+				case 1:
+					name = string(b)
+				case 2:
+					value = string(b)
+				}
+				return nil
+			})
+			s.labels[name] = value
+			return err
+		case 2:
+			var smp sample
+			err := forEachField(b, func(num protowire.Number, _ []byte, v uint64) error {
+				switch num {
+				case 1:
+					smp.value = math.Float64frombits(v)
+				case 2:
+					smp.time = time.UnixMilli(int64(v))
+				}
+				return nil
+			})
+			s.samples = append(s.samples, smp)
+			return err
+		}
+		return nil
+	})
+	return s, err
+}
+
+// forEachField calls fn for every field of a message: length-delimited fields get their bytes,
+// varint and fixed-size fields get their raw value.
+func forEachField(data []byte, fn func(num protowire.Number, b []byte, v uint64) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var b []byte
+		var v uint64
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.Fixed64Type:
+			v, n = protowire.ConsumeFixed64(data)
+		case protowire.Fixed32Type:
+			var v32 uint32
+			v32, n = protowire.ConsumeFixed32(data)
+			v = uint64(v32)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			return fmt.Errorf("unsupported wire type %d", typ)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if err := fn(num, b, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package remoteread
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/klauspost/compress/snappy"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	metricSoC         = "battery_RSoC"
+	metricCapacity    = "battery_RemainingCapacity_W"
+	metricPac         = "battery_Pac_total_W"
+	metricConsumption = "battery_Consumption_W"
+	metricStatus      = "battery_Status"
+	labelName         = "name"
+)
+
+var (
+	httpClient  = &http.Client{}
+	ErrReadOnly = errors.New("prometheus remote read store is read-only")
+)
+
+// Client reads battery history from Prometheus-compatible storage (Prometheus, Thanos, Cortex)
+// over the remote read protocol, using the series exported by the observers package.
+// Sessions are reconstructed from the battery_Status series: every continuous run of the
+// "discharging" status is one session. It implements the query side of storage.EventStore.
+type Client struct {
+	url string
+	log *slog.Logger
+}
+
+// New creates a client for the remote read endpoint, e.g. http://prometheus:9090/api/v1/read.
+func New(url string, log *slog.Logger) *Client {
+	return &Client{
+		url: url,
+		log: log.With(sl.Module("storage.remote_read")),
+	}
+}
+
+func (c *Client) SaveSnapshot(_ context.Context, _ entity.BatterySnapshot) error {
+	return ErrReadOnly
+}
+
+func (c *Client) SaveSession(_ context.Context, _ entity.SessionSummary) error {
+	return ErrReadOnly
+}
+
+func (c *Client) QuerySnapshots(ctx context.Context, from, to time.Time) ([]entity.BatterySnapshot, error) {
+	return c.snapshots(ctx, from, to, "")
+}
+
+// QueryBatterySnapshots reads the series of one battery only, selected by the name label.
+func (c *Client) QueryBatterySnapshots(ctx context.Context, battery string, from, to time.Time) ([]entity.BatterySnapshot, error) {
+	return c.snapshots(ctx, from, to, battery)
+}
+
+// snapshots reads the snapshots of the named battery, or of all batteries if battery is empty.
+func (c *Client) snapshots(ctx context.Context, from, to time.Time, battery string) ([]entity.BatterySnapshot, error) {
+	var label string
+	if battery != "" {
+		label = labelName
+	}
+	series, err := c.read(ctx, from, to,
+		query{name: metricSoC, label: label, value: battery},
+		query{name: metricCapacity, label: label, value: battery},
+		query{name: metricPac, label: label, value: battery},
+		query{name: metricConsumption, label: label, value: battery})
+	if err != nil {
+		return nil, err
+	}
+	soc, capacity, pac, consumption := byBattery(series[0]), byBattery(series[1]), byBattery(series[2]), byBattery(series[3])
+
+	var snapshots []entity.BatterySnapshot
+	for battery, samples := range soc {
+		for _, s := range samples {
+			snapshots = append(snapshots, entity.BatterySnapshot{
+				Battery:      battery,
+				Time:         s.time,
+				SoC:          s.value,
+				CapacityWh:   valueAt(capacity[battery], s.time),
+				PacW:         valueAt(pac[battery], s.time),
+				ConsumptionW: valueAt(consumption[battery], s.time),
+			})
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.Before(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// QuerySessions returns synthetic sessions; the energy is estimated from the drop of remaining capacity.
+// Price, carbon intensity and SoC limit are not available in the metrics and are left at zero.
+func (c *Client) QuerySessions(ctx context.Context, from, to time.Time) ([]entity.SessionSummary, error) {
+	series, err := c.read(ctx, from, to,
+		query{name: metricStatus, label: "status", value: entity.Discharging.String()},
+		query{name: metricSoC},
+		query{name: metricCapacity})
+	if err != nil {
+		return nil, err
+	}
+	soc, capacity := byBattery(series[1]), byBattery(series[2])
+
+	var sessions []entity.SessionSummary
+	for battery, samples := range byBattery(series[0]) {
+		var session *entity.SessionSummary
+		for _, s := range samples {
+			switch {
+			case s.value == 1 && session == nil:
+				session = &entity.SessionSummary{Battery: battery, StartTime: s.time}
+			case s.value == 0 && session != nil:
+				session.StopTime = s.time
+				sessions = append(sessions, complete(*session, soc[battery], capacity[battery]))
+				session = nil
+			}
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+	return sessions, nil
+}
+
+func complete(session entity.SessionSummary, soc, capacity []sample) entity.SessionSummary {
+	session.StartSoC = valueAt(soc, session.StartTime)
+	session.EndSoC = valueAt(soc, session.StopTime)
+	session.EnergyWh = max(0, valueAt(capacity, session.StartTime)-valueAt(capacity, session.StopTime))
+	return session
+}
+
+// read executes the queries in one remote read request and returns the series of each query, in order.
+func (c *Client) read(ctx context.Context, from, to time.Time, queries ...query) ([][]series, error) {
+	body := snappy.Encode(nil, encodeReadRequest(from, to, queries))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Read-Version", "0.1.0")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		c.log.Error("remote read", sl.Err(err))
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	compressed, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing response: %w", err)
+	}
+	results, err := decodeReadResponse(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if len(results) != len(queries) {
+		return nil, fmt.Errorf("expected %d query results, got %d", len(queries), len(results))
+	}
+	return results, nil
+}
+
+// byBattery groups samples by the battery name label; series of one battery are merged and sorted.
+func byBattery(list []series) map[string][]sample {
+	grouped := make(map[string][]sample)
+	for _, s := range list {
+		name := s.labels[labelName]
+		grouped[name] = append(grouped[name], s.samples...)
+	}
+	for _, samples := range grouped {
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].time.Before(samples[j].time)
+		})
+	}
+	return grouped
+}
+
+// valueAt returns the value of the last sample at or before t, or of the first sample if all are later.
+func valueAt(samples []sample, t time.Time) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	i := sort.Search(len(samples), func(i int) bool {
+		return samples[i].time.After(t)
+	})
+	if i == 0 {
+		return samples[0].value
+	}
+	return samples[i-1].value
+}
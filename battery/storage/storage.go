@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"gok-pi/battery/entity"
+	"time"
+)
+
+// EventStore keeps the history of battery readings and discharge sessions.
+// Queries return records with a timestamp in [from, to), ordered by time.
+type EventStore interface {
+	SaveSnapshot(ctx context.Context, snapshot entity.BatterySnapshot) error
+	SaveSession(ctx context.Context, session entity.SessionSummary) error
+	QuerySnapshots(ctx context.Context, from, to time.Time) ([]entity.BatterySnapshot, error)
+	QueryBatterySnapshots(ctx context.Context, battery string, from, to time.Time) ([]entity.BatterySnapshot, error)
+	QuerySessions(ctx context.Context, from, to time.Time) ([]entity.SessionSummary, error)
+}
+
+// WeekStart returns midnight of the Monday starting the week that contains t, in the location of t.
+func WeekStart(t time.Time) time.Time {
+	year, month, day := t.Date()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+}
+
+// QueryWeeklyAggregate sums up the sessions of all batteries started in the week beginning at weekStart.
+func QueryWeeklyAggregate(ctx context.Context, store EventStore, weekStart time.Time) (entity.WeeklyStats, error) {
+	stats := entity.WeeklyStats{WeekStart: weekStart}
+	sessions, err := store.QuerySessions(ctx, weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		return stats, err
+	}
+	for _, session := range sessions {
+		stats.TotalEnergyDischargedWh += session.EnergyWh
+		stats.TotalCostSaved += session.CostSaved
+		stats.TotalCO2SavedKg += session.CO2SavedKg
+		stats.AvgEndSoC += session.EndSoC
+	}
+	stats.SessionsCompleted = len(sessions)
+	if stats.SessionsCompleted > 0 {
+		stats.AvgEndSoC /= float64(stats.SessionsCompleted)
+	}
+	return stats, nil
+}
@@ -0,0 +1,233 @@
+package timescaledb
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/sl"
+	"log/slog"
+	"time"
+)
+
+// migrations are executed one by one: continuous aggregates cannot be created inside
+// the implicit transaction of a multi-statement query.
+var migrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+	`CREATE TABLE IF NOT EXISTS battery_snapshots (
+		time           TIMESTAMPTZ      NOT NULL,
+		battery        TEXT             NOT NULL,
+		soc            DOUBLE PRECISION NOT NULL,
+		capacity_wh    DOUBLE PRECISION NOT NULL,
+		pac_w          DOUBLE PRECISION NOT NULL,
+		consumption_w  DOUBLE PRECISION NOT NULL,
+		production_w   DOUBLE PRECISION NOT NULL,
+		grid_feed_in_w DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (battery, time)
+	)`,
+	`SELECT create_hypertable('battery_snapshots', 'time', if_not_exists => TRUE)`,
+	// the primary key already serves queries by battery and time, in either order
+	`DROP INDEX IF EXISTS battery_snapshots_battery_time_idx`,
+	`CREATE TABLE IF NOT EXISTS discharge_sessions (
+		start_time       TIMESTAMPTZ      NOT NULL,
+		battery          TEXT             NOT NULL,
+		stop_time        TIMESTAMPTZ      NOT NULL,
+		start_soc        DOUBLE PRECISION NOT NULL,
+		end_soc          DOUBLE PRECISION NOT NULL,
+		soc_limit        DOUBLE PRECISION NOT NULL,
+		energy_wh        DOUBLE PRECISION NOT NULL,
+		peak_price       DOUBLE PRECISION NOT NULL,
+		cost_saved       DOUBLE PRECISION NOT NULL,
+		carbon_intensity DOUBLE PRECISION NOT NULL,
+		co2_saved_kg     DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (battery, start_time)
+	)`,
+	`SELECT create_hypertable('discharge_sessions', 'start_time', if_not_exists => TRUE)`,
+	`ALTER TABLE discharge_sessions ADD COLUMN IF NOT EXISTS stop_reason TEXT NOT NULL DEFAULT ''`,
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS battery_daily
+	WITH (timescaledb.continuous) AS
+	SELECT time_bucket('1 day', time) AS day,
+		battery,
+		avg(soc) AS avg_soc,
+		min(soc) AS min_soc,
+		max(soc) AS max_soc,
+		count(*) AS samples
+	FROM battery_snapshots
+	GROUP BY day, battery
+	WITH NO DATA`,
+	`SELECT add_continuous_aggregate_policy('battery_daily',
+		start_offset => INTERVAL '3 days',
+		end_offset => INTERVAL '1 hour',
+		schedule_interval => INTERVAL '1 hour',
+		if_not_exists => TRUE)`,
+	// real-time aggregation: queries combine the materialized days with the rows not yet materialized
+	`ALTER MATERIALIZED VIEW battery_daily SET (timescaledb.materialized_only = false)`,
+}
+
+// DailySummary is a row of the battery_daily continuous aggregate.
+type DailySummary struct {
+	Day     time.Time `json:"day"`
+	Battery string    `json:"battery"`
+	AvgSoC  float64   `json:"avg_soc"`
+	MinSoC  float64   `json:"min_soc"`
+	MaxSoC  float64   `json:"max_soc"`
+	Samples int64     `json:"samples"`
+}
+
+// Store implements storage.EventStore on TimescaleDB hypertables.
+type Store struct {
+	pool *pgxpool.Pool
+	log  *slog.Logger
+}
+
+// New connects to the database and applies the schema migrations.
+func New(ctx context.Context, dsn string, log *slog.Logger) (*Store, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+	s := &Store{
+		pool: pool,
+		log:  log.With(sl.Module("storage.timescaledb")),
+	}
+	if err = s.migrate(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) Close() {
+	s.pool.Close()
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	for i, query := range migrations {
+		if _, err := s.pool.Exec(ctx, query); err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+	}
+	s.log.Debug("schema migrated")
+	return nil
+}
+
+// SaveSnapshot is idempotent: a repeated snapshot of the same battery and time is ignored.
+func (s *Store) SaveSnapshot(ctx context.Context, snapshot entity.BatterySnapshot) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO battery_snapshots (time, battery, soc, capacity_wh, pac_w, consumption_w, production_w, grid_feed_in_w)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING`,
+		snapshot.Time, snapshot.Battery, snapshot.SoC, snapshot.CapacityWh, snapshot.PacW,
+		snapshot.ConsumptionW, snapshot.ProductionW, snapshot.GridFeedInW)
+	if err != nil {
+		return fmt.Errorf("inserting snapshot: %w", err)
+	}
+	return nil
+}
+
+// SaveSession is idempotent: a repeated session of the same battery and start time is ignored.
+func (s *Store) SaveSession(ctx context.Context, session entity.SessionSummary) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO discharge_sessions (start_time, battery, stop_time, start_soc, end_soc, soc_limit,
+			energy_wh, peak_price, cost_saved, carbon_intensity, co2_saved_kg, stop_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT DO NOTHING`,
+		session.StartTime, session.Battery, session.StopTime, session.StartSoC, session.EndSoC, session.SocLimit,
+		session.EnergyWh, session.PeakPrice, session.CostSaved, session.CarbonIntensity, session.CO2SavedKg,
+		session.StopReason)
+	if err != nil {
+		return fmt.Errorf("inserting session: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) QuerySnapshots(ctx context.Context, from, to time.Time) ([]entity.BatterySnapshot, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT time, battery, soc, capacity_wh, pac_w, consumption_w, production_w, grid_feed_in_w
+		FROM battery_snapshots
+		WHERE time >= $1 AND time < $2
+		ORDER BY time`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying snapshots: %w", err)
+	}
+	return scanSnapshots(rows)
+}
+
+// QueryBatterySnapshots reads the snapshots of one battery, using the (battery, time) primary key.
+func (s *Store) QueryBatterySnapshots(ctx context.Context, battery string, from, to time.Time) ([]entity.BatterySnapshot, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT time, battery, soc, capacity_wh, pac_w, consumption_w, production_w, grid_feed_in_w
+		FROM battery_snapshots
+		WHERE battery = $1 AND time >= $2 AND time < $3
+		ORDER BY time`, battery, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying snapshots: %w", err)
+	}
+	return scanSnapshots(rows)
+}
+
+// scanSnapshots reads the rows of a snapshot query and closes them.
+func scanSnapshots(rows pgx.Rows) ([]entity.BatterySnapshot, error) {
+	defer rows.Close()
+
+	var snapshots []entity.BatterySnapshot
+	for rows.Next() {
+		var sn entity.BatterySnapshot
+		err := rows.Scan(&sn.Time, &sn.Battery, &sn.SoC, &sn.CapacityWh, &sn.PacW,
+			&sn.ConsumptionW, &sn.ProductionW, &sn.GridFeedInW)
+		if err != nil {
+			return nil, fmt.Errorf("scanning snapshot: %w", err)
+		}
+		snapshots = append(snapshots, sn)
+	}
+	return snapshots, rows.Err()
+}
+
+func (s *Store) QuerySessions(ctx context.Context, from, to time.Time) ([]entity.SessionSummary, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT start_time, battery, stop_time, start_soc, end_soc, soc_limit,
+			energy_wh, peak_price, cost_saved, carbon_intensity, co2_saved_kg, stop_reason
+		FROM discharge_sessions
+		WHERE start_time >= $1 AND start_time < $2
+		ORDER BY start_time`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []entity.SessionSummary
+	for rows.Next() {
+		var ss entity.SessionSummary
+		err = rows.Scan(&ss.StartTime, &ss.Battery, &ss.StopTime, &ss.StartSoC, &ss.EndSoC, &ss.SocLimit,
+			&ss.EnergyWh, &ss.PeakPrice, &ss.CostSaved, &ss.CarbonIntensity, &ss.CO2SavedKg, &ss.StopReason)
+		if err != nil {
+			return nil, fmt.Errorf("scanning session: %w", err)
+		}
+		sessions = append(sessions, ss)
+	}
+	return sessions, rows.Err()
+}
+
+// QueryDailySummaries reads the daily continuous aggregate, including days not yet materialized.
+func (s *Store) QueryDailySummaries(ctx context.Context, from, to time.Time) ([]DailySummary, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT day, battery, avg_soc, min_soc, max_soc, samples
+		FROM battery_daily
+		WHERE day >= $1 AND day < $2
+		ORDER BY day, battery`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("querying daily summaries: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []DailySummary
+	for rows.Next() {
+		var ds DailySummary
+		if err = rows.Scan(&ds.Day, &ds.Battery, &ds.AvgSoC, &ds.MinSoC, &ds.MaxSoC, &ds.Samples); err != nil {
+			return nil, fmt.Errorf("scanning daily summary: %w", err)
+		}
+		summaries = append(summaries, ds)
+	}
+	return summaries, rows.Err()
+}
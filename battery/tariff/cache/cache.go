@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"gok-pi/battery/tariff"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// Cache wraps a TariffSource and remembers one price per price slot of the source,
+// so repeated lookups during planning don't reach the underlying API.
+type Cache struct {
+	source  tariff.TariffSource
+	slot    time.Duration
+	ttl     time.Duration
+	entries sync.Map
+}
+
+// New caches the prices of a source whose prices change every slot, e.g. an hour or half an hour;
+// slots are counted from midnight.
+func New(source tariff.TariffSource, slot, ttl time.Duration) tariff.TariffSource {
+	return &Cache{
+		source: source,
+		slot:   slot,
+		ttl:    ttl,
+	}
+}
+
+func (c *Cache) PriceAt(t time.Time) (float64, error) {
+	c.evict()
+
+	start := c.slotStart(t)
+	key := start.Unix()
+	if value, ok := c.entries.Load(key); ok {
+		return value.(entry).price, nil
+	}
+
+	price, err := c.source.PriceAt(start)
+	if err != nil {
+		return 0, err
+	}
+	c.entries.Store(key, entry{price: price, fetchedAt: time.Now()})
+	return price, nil
+}
+
+// slotStart returns the start of the price slot containing t.
+func (c *Cache) slotStart(t time.Time) time.Time {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return midnight.Add(t.Sub(midnight).Truncate(c.slot))
+}
+
+// evict removes entries stored longer than ttl ago.
+func (c *Cache) evict() {
+	c.entries.Range(func(key, value any) bool {
+		if time.Since(value.(entry).fetchedAt) > c.ttl {
+			c.entries.Delete(key)
+		}
+		return true
+	})
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// slotPrices returns the minute of the hour as the price and counts the lookups.
+type slotPrices struct {
+	calls int
+}
+
+func (s *slotPrices) PriceAt(t time.Time) (float64, error) {
+	s.calls++
+	return float64(t.Minute()), nil
+}
+
+func TestHalfHourSlots(t *testing.T) {
+	source := &slotPrices{}
+	c := New(source, 30*time.Minute, time.Hour)
+	day := time.Date(2024, time.November, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		at   time.Duration
+		want float64
+	}{
+		{10 * time.Hour, 0},
+		{10*time.Hour + 15*time.Minute, 0},
+		{10*time.Hour + 30*time.Minute, 30},
+		{10*time.Hour + 45*time.Minute, 30},
+		{10*time.Hour + 59*time.Minute, 30},
+	}
+	for _, tt := range tests {
+		at := day.Add(tt.at)
+		price, err := c.PriceAt(at)
+		if err != nil {
+			t.Fatalf("price at %s: %v", at.Format(time.TimeOnly), err)
+		}
+		if price != tt.want {
+			t.Errorf("price at %s: got %v, want the price of the slot starting at minute %v",
+				at.Format(time.TimeOnly), price, tt.want)
+		}
+	}
+	if source.calls != 2 {
+		t.Errorf("%d source lookups, want one per slot", source.calls)
+	}
+}
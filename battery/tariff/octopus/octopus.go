@@ -0,0 +1,143 @@
+package octopus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gok-pi/battery/tariff"
+	"gok-pi/internal/lib/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SlotDuration is the period of an Agile unit rate.
+const SlotDuration = 30 * time.Minute
+
+const (
+	apiUrl   = "https://api.octopus.energy/v1"
+	cacheTtl = 30 * time.Minute
+	pageSize = "1500"
+)
+
+var httpClient = &http.Client{}
+
+type rate struct {
+	ValueIncVat float64   `json:"value_inc_vat"`
+	ValidFrom   time.Time `json:"valid_from"`
+	ValidTo     time.Time `json:"valid_to"`
+}
+
+type ratesResponse struct {
+	Results []rate `json:"results"`
+}
+
+// OctopusClient implements tariff.TariffSource with Agile Octopus half-hourly unit rates.
+type OctopusClient struct {
+	apiKey     string
+	product    string
+	tariffCode string
+	rates      []rate
+	fetchedAt  time.Time
+	mutex      sync.Mutex
+	log        *slog.Logger
+}
+
+// New creates a client for the given product (e.g. "AGILE-24-10-01") and regional
+// tariff code (e.g. "E-1R-AGILE-24-10-01-C").
+func New(apiKey, product, tariffCode string, log *slog.Logger) *OctopusClient {
+	log.With(
+		slog.String("product", product),
+		slog.String("tariff", tariffCode),
+		sl.Secret("api_key", apiKey),
+	).Info("creating octopus tariff client")
+	return &OctopusClient{
+		apiKey:     apiKey,
+		product:    product,
+		tariffCode: tariffCode,
+		log:        log.With(sl.Module("tariff.octopus")),
+	}
+}
+
+// PriceAt returns the unit rate including VAT in pounds per kWh.
+// Agile prices for the next day are published in the afternoon; until then
+// the price of the same half-hour slot on the previous day is returned.
+func (c *OctopusClient) PriceAt(t time.Time) (float64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.rates == nil || time.Since(c.fetchedAt) > cacheTtl {
+		rates, err := c.fetchRates(time.Now())
+		if err != nil {
+			return 0, err
+		}
+		c.rates = rates
+		c.fetchedAt = time.Now()
+	}
+
+	if price, ok := c.find(t); ok {
+		return price, nil
+	}
+	if price, ok := c.find(t.AddDate(0, 0, -1)); ok {
+		c.log.With(slog.Time("time", t)).Debug("price not published yet, using previous day")
+		return price, nil
+	}
+	return 0, tariff.ErrNoPrice
+}
+
+func (c *OctopusClient) find(t time.Time) (float64, bool) {
+	for _, r := range c.rates {
+		if !t.Before(r.ValidFrom) && t.Before(r.ValidTo) {
+			return r.ValueIncVat / 100, true
+		}
+	}
+	return 0, false
+}
+
+// fetchRates loads unit rates from the start of the previous day to the end of the next day,
+// so the previous-day fallback is always available.
+func (c *OctopusClient) fetchRates(now time.Time) ([]rate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	query := url.Values{}
+	query.Set("period_from", dayStart.AddDate(0, 0, -1).UTC().Format(time.RFC3339))
+	query.Set("period_to", dayStart.AddDate(0, 0, 2).UTC().Format(time.RFC3339))
+	query.Set("page_size", pageSize)
+	path := fmt.Sprintf("%s/products/%s/electricity-tariffs/%s/standard-unit-rates/?%s",
+		apiUrl, c.product, c.tariffCode, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.apiKey, "")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timeout")
+		}
+		c.log.Error("fetching unit rates", sl.Err(err))
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+
+	var response ratesResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding unit rates: %w", err)
+	}
+	c.log.With(slog.Int("rates", len(response.Results))).Debug("fetched unit rates")
+	return response.Results, nil
+}
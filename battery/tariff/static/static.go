@@ -0,0 +1,115 @@
+package static
+
+import (
+	"fmt"
+	"github.com/ilyakaznacheev/cleanenv"
+	"strings"
+	"time"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+type Config struct {
+	OffPeakRate float64  `yaml:"off_peak_rate" env-default:"0"`
+	Periods     []Period `yaml:"periods"`
+}
+
+// Period is a rate applied on the listed days between start and end (HH:MM, local time).
+// A period with end before start runs overnight into the following day.
+type Period struct {
+	Days  []string `yaml:"days"`
+	Start string   `yaml:"start"`
+	End   string   `yaml:"end"`
+	Rate  float64  `yaml:"rate"`
+}
+
+// StaticTariff implements tariff.TariffSource with fixed time-of-use rates.
+// Rates are resolved through a lookup table with one entry per minute of the week.
+type StaticTariff struct {
+	offPeakRate float64
+	rates       []float64
+	periods     []int
+}
+
+// Load reads the tariff configuration from a YAML file.
+func Load(path string) (*StaticTariff, error) {
+	var conf Config
+	if err := cleanenv.ReadConfig(path, &conf); err != nil {
+		return nil, fmt.Errorf("reading tariff config: %w", err)
+	}
+	return New(conf)
+}
+
+// New validates the configuration and returns an error if any two periods overlap.
+func New(conf Config) (*StaticTariff, error) {
+	t := &StaticTariff{
+		offPeakRate: conf.OffPeakRate,
+		periods:     make([]int, minutesPerWeek),
+	}
+	for i := range t.periods {
+		t.periods[i] = -1
+	}
+	for i, p := range conf.Periods {
+		start, err := parseMinute(p.Start)
+		if err != nil {
+			return nil, fmt.Errorf("period %d: start: %w", i+1, err)
+		}
+		end, err := parseMinute(p.End)
+		if err != nil {
+			return nil, fmt.Errorf("period %d: end: %w", i+1, err)
+		}
+		if start == end {
+			return nil, fmt.Errorf("period %d: start and end are equal", i+1)
+		}
+		if len(p.Days) == 0 {
+			return nil, fmt.Errorf("period %d: no days specified", i+1)
+		}
+		length := (end - start + minutesPerDay) % minutesPerDay
+		for _, day := range p.Days {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("period %d: invalid day: %s", i+1, day)
+			}
+			first := int(weekday)*minutesPerDay + start
+			for m := first; m < first+length; m++ {
+				slot := m % minutesPerWeek
+				if other := t.periods[slot]; other >= 0 {
+					return nil, fmt.Errorf("period %d overlaps period %d on %s", i+1, other+1, day)
+				}
+				t.periods[slot] = i
+			}
+		}
+		t.rates = append(t.rates, p.Rate)
+	}
+	return t, nil
+}
+
+// PriceAt returns the rate of the period containing t, or the off-peak rate if none does.
+func (t *StaticTariff) PriceAt(at time.Time) (float64, error) {
+	minute := int(at.Weekday())*minutesPerDay + at.Hour()*60 + at.Minute()
+	if i := t.periods[minute]; i >= 0 {
+		return t.rates[i], nil
+	}
+	return t.offPeakRate, nil
+}
+
+func parseMinute(hhmm string) (int, error) {
+	parsed, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
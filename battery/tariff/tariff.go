@@ -0,0 +1,14 @@
+package tariff
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNoPrice = errors.New("no price available")
+
+// TariffSource provides the electricity import price, in currency units per kWh,
+// valid at a given point in time.
+type TariffSource interface {
+	PriceAt(t time.Time) (float64, error)
+}
@@ -0,0 +1,123 @@
+package tibber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	subscriptionUrl = "wss://websocket-api.tibber.com/v1-beta/gql/subscriptions"
+	wsProtocol      = "graphql-transport-ws"
+	userAgent       = "gok-pi"
+)
+
+const liveMeasurementQuery = `subscription {
+  liveMeasurement(homeId: "%s") {
+    timestamp
+    power
+    accumulatedConsumption
+    accumulatedCost
+    currency
+  }
+}`
+
+type LiveMeasurement struct {
+	Timestamp              time.Time `json:"timestamp"`
+	Power                  float64   `json:"power"`
+	AccumulatedConsumption float64   `json:"accumulatedConsumption"`
+	AccumulatedCost        float64   `json:"accumulatedCost"`
+	Currency               string    `json:"currency"`
+}
+
+type wsMessage struct {
+	Id      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Subscribe streams liveMeasurement updates of the home to the handler until the context
+// is cancelled or the connection fails. Cached prices are refreshed whenever a measurement
+// falls into a new price slot, so PriceAt follows price changes without waiting for the cache TTL.
+// A Tibber Pulse or Watty must be installed in the home; homeId is required here.
+func (c *TibberClient) Subscribe(ctx context.Context, handler func(LiveMeasurement)) error {
+	if c.homeId == "" {
+		return fmt.Errorf("home id is required for live measurements")
+	}
+	header := http.Header{}
+	header.Set("User-Agent", userAgent)
+	dialer := websocket.Dialer{
+		Subprotocols:     []string{wsProtocol},
+		HandshakeTimeout: 10 * time.Second,
+	}
+	conn, _, err := dialer.DialContext(ctx, subscriptionUrl, header)
+	if err != nil {
+		return fmt.Errorf("connecting to subscription: %w", err)
+	}
+	defer func(conn *websocket.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	init, _ := json.Marshal(map[string]string{"token": c.token})
+	if err = conn.WriteJSON(wsMessage{Type: "connection_init", Payload: init}); err != nil {
+		return fmt.Errorf("sending connection init: %w", err)
+	}
+	var ack wsMessage
+	if err = conn.ReadJSON(&ack); err != nil {
+		return fmt.Errorf("reading connection ack: %w", err)
+	}
+	if ack.Type != "connection_ack" {
+		return fmt.Errorf("unexpected message: %s", ack.Type)
+	}
+
+	subscribe, _ := json.Marshal(map[string]string{"query": fmt.Sprintf(liveMeasurementQuery, c.homeId)})
+	if err = conn.WriteJSON(wsMessage{Id: "1", Type: "subscribe", Payload: subscribe}); err != nil {
+		return fmt.Errorf("sending subscribe: %w", err)
+	}
+	c.log.Info("subscribed to live measurements")
+
+	var slot time.Time
+	for {
+		var msg wsMessage
+		if err = conn.ReadJSON(&msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("reading subscription: %w", err)
+		}
+		switch msg.Type {
+		case "next":
+			var payload struct {
+				Data struct {
+					LiveMeasurement LiveMeasurement `json:"liveMeasurement"`
+				} `json:"data"`
+			}
+			if err = json.Unmarshal(msg.Payload, &payload); err != nil {
+				c.log.Warn("decoding live measurement", slog.String("payload", string(msg.Payload)))
+				continue
+			}
+			measurement := payload.Data.LiveMeasurement
+			current := measurement.Timestamp.Truncate(SlotDuration)
+			if !slot.IsZero() && !current.Equal(slot) {
+				c.invalidate()
+			}
+			slot = current
+			handler(measurement)
+		case "ping":
+			_ = conn.WriteJSON(wsMessage{Type: "pong"})
+		case "error":
+			return fmt.Errorf("subscription error: %s", string(msg.Payload))
+		case "complete":
+			return nil
+		}
+	}
+}
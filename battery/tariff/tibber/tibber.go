@@ -0,0 +1,182 @@
+package tibber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gok-pi/battery/tariff"
+	"gok-pi/internal/lib/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SlotDuration is the period of a Tibber price.
+const SlotDuration = time.Hour
+
+const (
+	apiUrl   = "https://api.tibber.com/v1-beta/gql"
+	cacheTtl = time.Hour
+)
+
+const priceQuery = `{
+  viewer {
+    homes {
+      id
+      currentSubscription {
+        priceInfo {
+          today { total startsAt }
+          tomorrow { total startsAt }
+        }
+      }
+    }
+  }
+}`
+
+var httpClient = &http.Client{}
+
+type price struct {
+	Total    float64   `json:"total"`
+	StartsAt time.Time `json:"startsAt"`
+}
+
+type priceResponse struct {
+	Data struct {
+		Viewer struct {
+			Homes []struct {
+				Id                  string `json:"id"`
+				CurrentSubscription *struct {
+					PriceInfo struct {
+						Today    []price `json:"today"`
+						Tomorrow []price `json:"tomorrow"`
+					} `json:"priceInfo"`
+				} `json:"currentSubscription"`
+			} `json:"homes"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []graphqlError `json:"errors"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+// TibberClient implements tariff.TariffSource with the hourly prices of a Tibber subscription.
+type TibberClient struct {
+	token     string
+	homeId    string
+	prices    []price
+	fetchedAt time.Time
+	mutex     sync.Mutex
+	log       *slog.Logger
+}
+
+// New creates a Tibber client; if homeId is empty, the first home of the account is used.
+func New(token, homeId string, log *slog.Logger) *TibberClient {
+	log.With(
+		slog.String("home", homeId),
+		sl.Secret("token", token),
+	).Info("creating tibber tariff client")
+	return &TibberClient{
+		token:  token,
+		homeId: homeId,
+		log:    log.With(sl.Module("tariff.tibber")),
+	}
+}
+
+// PriceAt returns the total price including taxes per kWh, in the currency of the subscription.
+func (c *TibberClient) PriceAt(t time.Time) (float64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.prices == nil || time.Since(c.fetchedAt) > cacheTtl {
+		prices, err := c.fetchPrices()
+		if err != nil {
+			return 0, err
+		}
+		c.prices = prices
+		c.fetchedAt = time.Now()
+	}
+
+	for i, p := range c.prices {
+		end := p.StartsAt.Add(SlotDuration)
+		if i+1 < len(c.prices) {
+			end = c.prices[i+1].StartsAt
+		}
+		if !t.Before(p.StartsAt) && t.Before(end) {
+			return p.Total, nil
+		}
+	}
+	return 0, tariff.ErrNoPrice
+}
+
+// invalidate drops cached prices so the next PriceAt call fetches them again.
+func (c *TibberClient) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.prices = nil
+}
+
+// fetchPrices returns today's and, when already published, tomorrow's prices of the selected home.
+func (c *TibberClient) fetchPrices() ([]price, error) {
+	var response priceResponse
+	if err := c.query(priceQuery, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("graphql: %s", response.Errors[0].Message)
+	}
+	for _, home := range response.Data.Viewer.Homes {
+		if c.homeId != "" && home.Id != c.homeId {
+			continue
+		}
+		if home.CurrentSubscription == nil {
+			return nil, fmt.Errorf("home %s has no active subscription", home.Id)
+		}
+		info := home.CurrentSubscription.PriceInfo
+		prices := append(info.Today, info.Tomorrow...)
+		c.log.With(slog.Int("prices", len(prices))).Debug("fetched prices")
+		return prices, nil
+	}
+	return nil, fmt.Errorf("home not found: %s", c.homeId)
+}
+
+func (c *TibberClient) query(query string, result interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return fmt.Errorf("marshalling query: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timeout")
+		}
+		c.log.Error("graphql request", sl.Err(err))
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
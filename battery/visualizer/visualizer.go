@@ -0,0 +1,112 @@
+package visualizer
+
+import (
+	"fmt"
+	"gok-pi/battery/entity"
+	"io"
+	"strings"
+)
+
+const (
+	chartWidth  = 60
+	chartHeight = 20
+	barChar     = "█"
+
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorGreen  = "\033[32m"
+
+	socHigh = 60.0
+	socLow  = 30.0
+)
+
+// NoColor disables ANSI colour codes in the output; commands bind it to a --no-color flag.
+var NoColor = false
+
+// PrintSoCChart renders the SoC history as a bar chart of 60 columns by 20 rows.
+// History is split into equal time buckets, one per column, and each bar shows the bucket average.
+func PrintSoCChart(history []entity.BatterySnapshot, w io.Writer) error {
+	if len(history) == 0 {
+		_, err := fmt.Fprintln(w, "no data")
+		return err
+	}
+
+	columns := buckets(history)
+
+	var sb strings.Builder
+	for row := chartHeight; row > 0; row-- {
+		switch row {
+		case chartHeight:
+			sb.WriteString("100% |")
+		case chartHeight / 2:
+			sb.WriteString(" 50% |")
+		case 1:
+			sb.WriteString("  0% |")
+		default:
+			sb.WriteString("     |")
+		}
+		for _, soc := range columns {
+			if soc < 0 || barHeight(soc) < row {
+				sb.WriteString(" ")
+				continue
+			}
+			sb.WriteString(colorize(barChar, soc))
+		}
+		sb.WriteString("\n")
+	}
+
+	first := history[0].Time
+	last := history[len(history)-1].Time
+	sb.WriteString("     +" + strings.Repeat("-", chartWidth) + "\n")
+	start := first.Format("01-02 15:04")
+	end := last.Format("01-02 15:04")
+	sb.WriteString("      " + start + strings.Repeat(" ", max(1, chartWidth-len(start)-len(end))) + end + "\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// buckets averages the SoC per column; columns without readings are set to -1.
+func buckets(history []entity.BatterySnapshot) []float64 {
+	first := history[0].Time
+	span := history[len(history)-1].Time.Sub(first)
+
+	sum := make([]float64, chartWidth)
+	count := make([]int, chartWidth)
+	for _, s := range history {
+		column := chartWidth - 1
+		if span > 0 {
+			column = min(int(float64(s.Time.Sub(first))/float64(span)*chartWidth), chartWidth-1)
+		}
+		sum[column] += s.SoC
+		count[column]++
+	}
+
+	columns := make([]float64, chartWidth)
+	for i := range columns {
+		columns[i] = -1
+		if count[i] > 0 {
+			columns[i] = sum[i] / float64(count[i])
+		}
+	}
+	return columns
+}
+
+func barHeight(soc float64) int {
+	h := int(soc/entity.SoCFull*chartHeight + 0.5)
+	return min(max(h, 1), chartHeight)
+}
+
+func colorize(s string, soc float64) string {
+	if NoColor {
+		return s
+	}
+	color := colorGreen
+	if soc < socLow {
+		color = colorRed
+	} else if soc < socHigh {
+		color = colorYellow
+	}
+	return color + s + colorReset
+}
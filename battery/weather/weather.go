@@ -0,0 +1,127 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gok-pi/internal/lib/sl"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	apiUrl     = "https://api.open-meteo.com/v1/forecast"
+	cacheTtl   = time.Hour
+	dateFormat = "2006-01-02"
+)
+
+var httpClient = &http.Client{}
+
+type forecastResponse struct {
+	Hourly struct {
+		Time       []string  `json:"time"`
+		CloudCover []float64 `json:"cloud_cover"`
+	} `json:"hourly"`
+}
+
+type cacheEntry struct {
+	cover     []float64
+	fetchedAt time.Time
+}
+
+// Client fetches hourly cloud cover forecasts from Open-Meteo for a fixed location.
+type Client struct {
+	latitude  float64
+	longitude float64
+	cache     map[string]cacheEntry
+	mutex     sync.Mutex
+	log       *slog.Logger
+}
+
+func New(latitude, longitude float64, log *slog.Logger) *Client {
+	return &Client{
+		latitude:  latitude,
+		longitude: longitude,
+		cache:     make(map[string]cacheEntry),
+		log:       log.With(sl.Module("weather")),
+	}
+}
+
+// HourlyCover returns 24 cloud cover percentages for the calendar day of date,
+// indexed by hour in the local time of the configured location.
+func (c *Client) HourlyCover(date time.Time) ([]float64, error) {
+	day := date.Format(dateFormat)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, ok := c.cache[day]; ok && time.Since(entry.fetchedAt) < cacheTtl {
+		return entry.cover, nil
+	}
+	cover, err := c.fetch(day)
+	if err != nil {
+		return nil, err
+	}
+	c.cache[day] = cacheEntry{cover: cover, fetchedAt: time.Now()}
+	for key, entry := range c.cache {
+		if time.Since(entry.fetchedAt) > cacheTtl {
+			delete(c.cache, key)
+		}
+	}
+	return cover, nil
+}
+
+func (c *Client) fetch(day string) ([]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("latitude", fmt.Sprintf("%.4f", c.latitude))
+	query.Set("longitude", fmt.Sprintf("%.4f", c.longitude))
+	query.Set("hourly", "cloud_cover")
+	query.Set("timezone", "auto")
+	query.Set("start_date", day)
+	query.Set("end_date", day)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("request timeout")
+		}
+		c.log.Error("fetching forecast", sl.Err(err))
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	var response forecastResponse
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding forecast: %w", err)
+	}
+	if len(response.Hourly.CloudCover) != 24 {
+		return nil, fmt.Errorf("unexpected number of hourly values: %d", len(response.Hourly.CloudCover))
+	}
+	c.log.With(slog.String("date", day)).Debug("fetched cloud cover forecast")
+	return response.Hourly.CloudCover, nil
+}
+
+// SolarFactor returns the fraction of clear-sky irradiance reaching the ground at the given
+// cloud cover percentage, using the Kasten-Czeplak model; multiply a clear-sky generation
+// estimate by it to account for clouds.
+func SolarFactor(cloudCover float64) float64 {
+	return 1 - 0.75*math.Pow(min(max(cloudCover, 0), 100)/100, 3.4)
+}
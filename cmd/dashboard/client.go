@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"gok-pi/battery/discharger"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type apiClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// newApiClient returns a client of the gok-pi API; the token, if set, is sent as a bearer token,
+// as required when the API checks JWTs.
+func newApiClient(url, token string) *apiClient {
+	return &apiClient{
+		url:        strings.TrimSuffix(url, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *apiClient) status() ([]discharger.State, error) {
+	resp, err := c.do(http.MethodGet, "/status")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	var states []discharger.State
+	if err = json.NewDecoder(resp.Body).Decode(&states); err != nil {
+		return nil, fmt.Errorf("decoding status: %w", err)
+	}
+	return states, nil
+}
+
+func (c *apiClient) post(path string) error {
+	resp, err := c.do(http.MethodPost, path)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("received status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *apiClient) do(method, path string) (*http.Response, error) {
+	req, err := http.NewRequest(method, c.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.httpClient.Do(req)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"os"
+)
+
+func main() {
+	apiUrl := flag.String("url", "http://127.0.0.1:5002", "base url of the gok-pi api")
+	token := flag.String("token", "", "JWT sent as bearer token; an operator token is needed for forced start and stop")
+	flag.Parse()
+
+	program := tea.NewProgram(newModel(*apiUrl, *token), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		fmt.Println("dashboard:", err)
+		os.Exit(1)
+	}
+}
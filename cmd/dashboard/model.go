@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"gok-pi/battery/discharger"
+	"gok-pi/battery/entity"
+	"gok-pi/internal/lib/timer"
+	"strings"
+	"time"
+)
+
+const (
+	refreshInterval = 5 * time.Second
+	barWidth        = 30
+	maxEvents       = 8
+)
+
+type statusMsg struct {
+	states []discharger.State
+	err    error
+}
+
+type commandMsg struct {
+	action string
+	err    error
+}
+
+type tickMsg time.Time
+
+type model struct {
+	client  *apiClient
+	states  []discharger.State
+	events  []string
+	updated time.Time
+	err     error
+}
+
+func newModel(url, token string) model {
+	return model{client: newApiClient(url, token)}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.fetchStatus
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "f", "F":
+			return m, m.toggleDischarge
+		}
+	case tickMsg:
+		return m, m.fetchStatus
+	case statusMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.logChanges(msg.states)
+			m.states = msg.states
+			m.updated = time.Now()
+		}
+		return m, tea.Tick(refreshInterval, func(t time.Time) tea.Msg { return tickMsg(t) })
+	case commandMsg:
+		if msg.err != nil {
+			m.addEvent(fmt.Sprintf("%s failed: %v", msg.action, msg.err))
+		} else {
+			m.addEvent(fmt.Sprintf("%s requested", msg.action))
+		}
+		return m, m.fetchStatus
+	}
+	return m, nil
+}
+
+func (m model) View() string {
+	var sb strings.Builder
+	sb.WriteString("gok-pi dashboard")
+	if !m.updated.IsZero() {
+		sb.WriteString(fmt.Sprintf("  (updated %s)", m.updated.Format("15:04:05")))
+	}
+	sb.WriteString("\n\n")
+
+	if m.err != nil {
+		sb.WriteString(fmt.Sprintf("error: %v\n\n", m.err))
+	}
+	for _, s := range m.states {
+		sb.WriteString(renderBattery(s))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("Events:\n")
+	for _, e := range m.events {
+		sb.WriteString("  " + e + "\n")
+	}
+	sb.WriteString("\n[f] force start/stop discharge  [q] quit\n")
+	return sb.String()
+}
+
+func (m model) fetchStatus() tea.Msg {
+	states, err := m.client.status()
+	return statusMsg{states: states, err: err}
+}
+
+// toggleDischarge stops discharge if any battery is discharging, otherwise starts it on all batteries.
+func (m model) toggleDischarge() tea.Msg {
+	for _, s := range m.states {
+		if s.IsDischarging {
+			return commandMsg{action: "stop", err: m.client.post("/discharge/stop")}
+		}
+	}
+	return commandMsg{action: "start", err: m.client.post("/discharge/start")}
+}
+
+func (m *model) logChanges(states []discharger.State) {
+	previous := make(map[string]bool)
+	for _, s := range m.states {
+		previous[s.Name] = s.IsDischarging
+	}
+	for _, s := range states {
+		was, ok := previous[s.Name]
+		if ok && was == s.IsDischarging {
+			continue
+		}
+		if s.IsDischarging {
+			m.addEvent(fmt.Sprintf("%s: discharging", s.Name))
+		} else if ok {
+			m.addEvent(fmt.Sprintf("%s: discharge stopped", s.Name))
+		}
+	}
+}
+
+func (m *model) addEvent(text string) {
+	m.events = append(m.events, fmt.Sprintf("%s %s", time.Now().Format("15:04:05"), text))
+	if len(m.events) > maxEvents {
+		m.events = m.events[len(m.events)-maxEvents:]
+	}
+}
+
+func renderBattery(s discharger.State) string {
+	var sb strings.Builder
+	state := "idle"
+	if s.IsDischarging {
+		state = "discharging"
+	}
+	if s.Forced {
+		state += " (forced)"
+	}
+	sb.WriteString(fmt.Sprintf("%s — %s\n", s.Name, state))
+	if s.Status == nil {
+		sb.WriteString("  no status yet\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("  SoC         %s %5.1f%%\n", progressBar(s.Status.RSOC), s.Status.RSOC))
+	sb.WriteString(fmt.Sprintf("  Pac         %8.0f W\n", s.Status.PacTotalW))
+	sb.WriteString(fmt.Sprintf("  Consumption %8.0f W\n", s.Status.ConsumptionW))
+	sb.WriteString(fmt.Sprintf("  Grid        %8.0f W\n", s.Status.GridFeedInW))
+	sb.WriteString(fmt.Sprintf("  Next session %s\n", nextSession(s)))
+	return sb.String()
+}
+
+func progressBar(soc float64) string {
+	filled := int(soc / entity.SoCFull * barWidth)
+	filled = min(max(filled, 0), barWidth)
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", barWidth-filled) + "]"
+}
+
+func nextSession(s discharger.State) string {
+	if s.IsDischarging {
+		return "in progress"
+	}
+	start, err := timer.NextDayTime(time.Now(), s.StartTime)
+	if err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("in %s (%s)", time.Until(start).Truncate(time.Second), s.StartTime)
+}
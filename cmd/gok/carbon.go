@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"gok-pi/battery/carbon/static"
+	"gok-pi/battery/carbon/ukgrid"
+	"gok-pi/battery/discharger"
+	"gok-pi/internal/config"
+	"log/slog"
+)
+
+// newCarbon creates the carbon intensity source selected in the configuration.
+func newCarbon(conf config.Carbon, log *slog.Logger) (discharger.CarbonSource, error) {
+	switch conf.Source {
+	case "static":
+		if conf.StaticIntensity <= 0 {
+			return nil, fmt.Errorf("static carbon intensity must be positive: %v", conf.StaticIntensity)
+		}
+		return static.New(conf.StaticIntensity), nil
+	case "ukgrid":
+		return ukgrid.New(log), nil
+	default:
+		return nil, fmt.Errorf("unknown carbon source: %q", conf.Source)
+	}
+}
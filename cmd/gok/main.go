@@ -1,17 +1,30 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"gok-pi/battery/api"
 	"gok-pi/battery/api-client"
+	"gok-pi/battery/api/auth"
 	"gok-pi/battery/discharger"
+	"gok-pi/battery/fleet"
+	"gok-pi/battery/load"
+	"gok-pi/battery/storage/timescaledb"
 	"gok-pi/internal/config"
 	"gok-pi/internal/lib/logger"
 	"gok-pi/internal/lib/sl"
+	"gok-pi/internal/lib/timer"
 	"gok-pi/metrics/server"
 	"log/slog"
-	"sync"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout is how long the discharge workers may take to restore automatic mode on shutdown.
+const shutdownTimeout = time.Minute
+
 func main() {
 
 	configPath := flag.String("conf", "config.yml", "path to config file")
@@ -23,6 +36,24 @@ func main() {
 
 	lg.Info("starting gok-pi", slog.String("config", *configPath), slog.String("env", conf.Env))
 	lg.Debug("debug messages enabled")
+
+	if conf.Location.Timezone != "" {
+		loc, err := time.LoadLocation(conf.Location.Timezone)
+		if err != nil {
+			lg.Error("loading timezone", sl.Err(err))
+			return
+		}
+		time.Local = loc
+	}
+	if conf.Location.IsSet() {
+		timer.SetCoordinates(conf.Location.Latitude, conf.Location.Longitude)
+		lg.With(
+			slog.Float64("latitude", conf.Location.Latitude),
+			slog.Float64("longitude", conf.Location.Longitude),
+			slog.String("timezone", time.Local.String()),
+		).Info("location configured")
+	}
+
 	// filter enabled batteries
 	var batteries []config.BatteryConfig
 	for _, b := range conf.Batteries {
@@ -50,32 +81,89 @@ func main() {
 		}()
 	}
 
-	var wg sync.WaitGroup
+	apiServer := api.New(lg)
+	if conf.Api.AdminToken != "" {
+		apiServer.EnableAdmin(conf.Api.AdminToken)
+	}
+	if conf.Api.JwtPublicKey != "" {
+		key, err := auth.LoadPublicKey(conf.Api.JwtPublicKey)
+		if err != nil {
+			lg.Error("loading jwt public key", sl.Err(err))
+			return
+		}
+		apiServer.EnableJWT(key)
+	}
+	var options []discharger.Option
+	if conf.Tariff.Source != "" {
+		source, err := newTariff(conf.Tariff, lg)
+		if err != nil {
+			lg.Error("creating tariff source", sl.Err(err))
+			return
+		}
+		apiServer.EnableApplianceScheduling(load.New(lg), source)
+		options = append(options, discharger.WithTariffSource(source))
+	}
+	if conf.Carbon.Source != "" {
+		source, err := newCarbon(conf.Carbon, lg)
+		if err != nil {
+			lg.Error("creating carbon source", sl.Err(err))
+			return
+		}
+		options = append(options, discharger.WithCarbonSource(source))
+	}
+	if conf.Storage.TimescaleDsn != "" {
+		store, err := timescaledb.New(context.Background(), conf.Storage.TimescaleDsn, lg)
+		if err != nil {
+			lg.Error("connecting to timescaledb", sl.Err(err))
+			return
+		}
+		defer store.Close()
+		lg.Info("event store enabled; serving history and analytics")
+		apiServer.EnableHistory(store)
+		options = append(options, discharger.WithEventStore(store))
+	}
+	if conf.Api.Enabled {
+		lg.Info("starting api server", slog.String("bind", conf.Api.Bind), slog.String("port", conf.Api.Port))
+		go func() {
+			err := apiServer.Listen(conf.Api.Bind, conf.Api.Port)
+			if err != nil {
+				lg.Error("api server", sl.Err(err))
+				return
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	for _, b := range batteries {
-		wg.Add(1)
-		go func(workerId string) {
-			defer wg.Done()
+	// a deadlocked worker shuts the daemon down; if it does not return either, exit without it
+	options = append(options, discharger.WithDeadlockShutdown(stop))
+	go func() {
+		<-ctx.Done()
+		time.Sleep(shutdownTimeout)
+		lg.Log(context.Background(), sl.LevelFatal, "discharge workers did not stop, exiting")
+		os.Exit(1)
+	}()
 
-			log := lg.With(slog.String("battery", workerId))
-			api := apiclient.New(b.Url, b.Token, log)
+	workers := fleet.New(ctx, apiServer, lg)
 
-			worker, err := discharger.New(workerId, api, log)
-			if err != nil {
-				log.Error("creating discharge worker", sl.Err(err))
-			}
+	for _, b := range batteries {
+		log := lg.With(slog.String("battery", b.Name))
+		api := apiclient.New(b.Url, b.Token, log)
 
-			worker.SetTime(conf.StartTime, conf.StopTime)
-			worker.SetLimits(b.CapacityLimit, b.PowerLimit, b.SocLimit)
+		worker, err := discharger.New(b.Name, api, lg, options...)
+		if err != nil {
+			log.Error("creating discharge worker", sl.Err(err))
+			continue
+		}
 
-			err = worker.Run()
-			if err != nil {
-				log.Error("running discharge worker", sl.Err(err))
-			}
-			log.Info("discharge worker stopped")
-		}(b.Name)
+		worker.SetTime(conf.StartTime, conf.StopTime)
+		worker.SetLimits(b.CapacityLimit, b.PowerLimit, b.SocLimit)
+		if err = workers.Register(b.Name, worker); err != nil {
+			log.Error("registering discharge worker", sl.Err(err))
+		}
 	}
-	wg.Wait()
+	workers.Wait()
 
 	lg.Info("gok-pi stopped")
 }
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"gok-pi/battery/tariff"
+	"gok-pi/battery/tariff/cache"
+	"gok-pi/battery/tariff/octopus"
+	"gok-pi/battery/tariff/static"
+	"gok-pi/battery/tariff/tibber"
+	"gok-pi/internal/config"
+	"log/slog"
+	"time"
+)
+
+// tariffCacheTTL limits how often supplier APIs are queried for the same price slot
+const tariffCacheTTL = time.Hour
+
+// newTariff creates the price source selected in the configuration.
+func newTariff(conf config.Tariff, log *slog.Logger) (tariff.TariffSource, error) {
+	switch conf.Source {
+	case "static":
+		return static.Load(conf.StaticPath)
+	case "octopus":
+		return cache.New(octopus.New(conf.OctopusApiKey, conf.OctopusProduct, conf.OctopusTariff, log), octopus.SlotDuration, tariffCacheTTL), nil
+	case "tibber":
+		return cache.New(tibber.New(conf.TibberToken, conf.TibberHomeId, log), tibber.SlotDuration, tariffCacheTTL), nil
+	default:
+		return nil, fmt.Errorf("unknown tariff source: %q", conf.Source)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gok-pi/battery/api/auth"
+	"os"
+	"strings"
+	"time"
+)
+
+// issue-token prints a JWT for the API, signed with the RSA private key at -key.
+// The server verifies it with the matching public key.
+
+func main() {
+	keyPath := flag.String("key", "jwt-private.pem", "path to the PEM encoded RSA private key")
+	subject := flag.String("sub", "", "user ID of the token holder")
+	roles := flag.String("role", "", "comma separated roles granted by the token: viewer, operator or admin")
+	ttl := flag.Duration("ttl", 24*time.Hour, "validity of the token")
+	flag.Parse()
+
+	if *subject == "" {
+		fail("-sub is required")
+	}
+	key, err := auth.LoadPrivateKey(*keyPath)
+	if err != nil {
+		fail("%v", err)
+	}
+	now := time.Now()
+	claims := auth.Claims{
+		Subject:   *subject,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(*ttl).Unix(),
+	}
+	if *roles != "" {
+		for _, name := range strings.Split(*roles, ",") {
+			role, err := auth.ParseRole(strings.TrimSpace(name))
+			if err != nil {
+				fail("%v", err)
+			}
+			claims.Roles = append(claims.Roles, role)
+		}
+	}
+	token, err := auth.Sign(claims, key)
+	if err != nil {
+		fail("%v", err)
+	}
+	fmt.Println(token)
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "issue-token: "+format+"\n", args...)
+	os.Exit(1)
+}
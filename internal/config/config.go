@@ -5,6 +5,7 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 	"log"
 	"sync"
+	"time"
 )
 
 type Config struct {
@@ -12,6 +13,11 @@ type Config struct {
 	StartTime string          `yaml:"start_time" env-default:"18:00"`
 	StopTime  string          `yaml:"stop_time" env-default:"22:00"`
 	Metrics   MetricsServer   `yaml:"metrics"`
+	Api       ApiServer       `yaml:"api"`
+	Location  Location        `yaml:"location"`
+	Tariff    Tariff          `yaml:"tariff"`
+	Carbon    Carbon          `yaml:"carbon"`
+	Storage   Storage         `yaml:"storage"`
 	Batteries []BatteryConfig `yaml:"batteries"`
 }
 
@@ -31,6 +37,70 @@ type MetricsServer struct {
 	Port    string `yaml:"port" env-default:"5001"`
 }
 
+// ApiServer configures the HTTP API; privileged endpoints such as battery reset are only available
+// when AdminToken is set. With JwtPublicKey set, every endpoint requires a JWT signed with the matching private key.
+type ApiServer struct {
+	Enabled      bool   `yaml:"enabled" env-default:"false"`
+	Bind         string `yaml:"bind" env-default:"127.0.0.1"`
+	Port         string `yaml:"port" env-default:"5002"`
+	AdminToken   string `yaml:"admin_token" env:"API_ADMIN_TOKEN" env-default:""`
+	JwtPublicKey string `yaml:"jwt_public_key" env:"API_JWT_PUBLIC_KEY" env-default:""`
+}
+
+// Tariff selects the source of electricity prices: "static" reads time-of-use rates from StaticPath,
+// "octopus" and "tibber" query the supplier API. Without a source, price-based features are disabled.
+type Tariff struct {
+	Source         string `yaml:"source" env-default:""`
+	StaticPath     string `yaml:"static_path" env-default:""`
+	OctopusApiKey  string `yaml:"octopus_api_key" env:"TARIFF_OCTOPUS_API_KEY" env-default:""`
+	OctopusProduct string `yaml:"octopus_product" env-default:""`
+	OctopusTariff  string `yaml:"octopus_tariff" env-default:""`
+	TibberToken    string `yaml:"tibber_token" env:"TARIFF_TIBBER_TOKEN" env-default:""`
+	TibberHomeId   string `yaml:"tibber_home_id" env-default:""`
+}
+
+// Carbon selects the source of grid carbon intensity used to estimate the CO2 avoided by discharging:
+// "static" uses StaticIntensity in gCO2/kWh at all times, "ukgrid" queries the GB national intensity.
+// Without a source, sessions report no CO2 savings.
+type Carbon struct {
+	Source          string  `yaml:"source" env-default:""`
+	StaticIntensity float64 `yaml:"static_intensity" env-default:"0"`
+}
+
+// Storage configures the event store keeping battery snapshots and session summaries. With TimescaleDsn
+// set, they are saved to TimescaleDB and the history and analytics endpoints are available.
+type Storage struct {
+	TimescaleDsn string `yaml:"timescale_dsn" env:"STORAGE_TIMESCALE_DSN" env-default:""`
+}
+
+// Location is the geographical position of the installation, used for solar time calculations.
+// Timezone is an IANA name; if empty, the system timezone is used.
+type Location struct {
+	Latitude  float64 `yaml:"latitude" env-default:"0"`
+	Longitude float64 `yaml:"longitude" env-default:"0"`
+	Timezone  string  `yaml:"timezone" env-default:""`
+}
+
+// IsSet reports whether coordinates were configured; 0,0 is taken as not configured.
+func (l Location) IsSet() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+func (l Location) Validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 {
+		return fmt.Errorf("location: latitude %v out of range [-90, 90]", l.Latitude)
+	}
+	if l.Longitude < -180 || l.Longitude > 180 {
+		return fmt.Errorf("location: longitude %v out of range [-180, 180]", l.Longitude)
+	}
+	if l.Timezone != "" {
+		if _, err := time.LoadLocation(l.Timezone); err != nil {
+			return fmt.Errorf("location: invalid timezone %q: %w", l.Timezone, err)
+		}
+	}
+	return nil
+}
+
 var instance *Config
 var once sync.Once
 
@@ -44,6 +114,10 @@ func MustLoad(path string) *Config {
 			instance = nil
 			log.Fatal(err)
 		}
+		if err = instance.Location.Validate(); err != nil {
+			instance = nil
+			log.Fatal(err)
+		}
 	})
 	return instance
 }
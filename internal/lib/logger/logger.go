@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"gok-pi/internal/lib/sl"
 	"log"
 	"log/slog"
 	"os"
@@ -31,15 +32,15 @@ func SetupLogger(env, path string) *slog.Logger {
 	switch env {
 	case envLocal:
 		logger = slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: replaceLevel}),
 		)
 	case envDev:
 		logger = slog.New(
-			slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: replaceLevel}),
 		)
 	case envProd:
 		logger = slog.New(
-			slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: slog.LevelInfo}),
+			slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: slog.LevelInfo, ReplaceAttr: replaceLevel}),
 		)
 	default:
 		log.Fatal("invalid environment: ", env)
@@ -48,6 +49,16 @@ func SetupLogger(env, path string) *slog.Logger {
 	return logger
 }
 
+// replaceLevel names sl.LevelFatal, which slog would print as ERROR+4.
+func replaceLevel(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == sl.LevelFatal {
+			a.Value = slog.StringValue("FATAL")
+		}
+	}
+	return a
+}
+
 func logFilePath(path string) string {
 	return fmt.Sprintf("%s/%s", path, logFileName)
 }
@@ -5,6 +5,9 @@ import (
 	"log/slog"
 )
 
+// LevelFatal is the level of errors after which the daemon shuts down; slog has no level above ERROR.
+const LevelFatal = slog.Level(12)
+
 func Err(err error) slog.Attr {
 	return slog.Attr{
 		Key:   "error",
@@ -0,0 +1,69 @@
+// Package testutil holds helpers shared by the tests of several packages.
+package testutil
+
+import (
+	"errors"
+	"gok-pi/battery/entity"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// WaitTimeout is how long WaitFor polls before failing the test.
+const WaitTimeout = 5 * time.Second
+
+// NewTestLogger returns a logger writing to the test log, so the output is shown only for failed
+// tests or with -v. Debug messages are dropped, since background goroutines of a worker may log
+// them after the test has ended.
+func NewTestLogger(t testing.TB) *slog.Logger {
+	return slog.New(slog.NewTextHandler(testWriter{t}, nil))
+}
+
+type testWriter struct {
+	t testing.TB
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Helper()
+	w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// MustMkdirTemp creates a directory that is removed with its contents when the test ends.
+func MustMkdirTemp(t testing.TB) string {
+	t.Helper()
+	return t.TempDir()
+}
+
+// WaitFor polls cond until it holds, failing the test after WaitTimeout.
+func WaitFor(t testing.TB, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(WaitTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// AssertErrorIs fails the test unless err matches target, as reported by errors.Is.
+func AssertErrorIs(t testing.TB, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("error %v, want %v", err, target)
+	}
+}
+
+// AssertBatteryInfoEqual fails the test if the battery infos differ in other fields than the system time,
+// which changes with every reading.
+func AssertBatteryInfoEqual(t testing.TB, expected, actual entity.BatteryInfo) {
+	t.Helper()
+	expected.SystemTime = 0
+	actual.SystemTime = 0
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("battery info\n got: %+v\nwant: %+v", actual, expected)
+	}
+}
@@ -0,0 +1,56 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeRange is a half-open interval [Start, Stop) between two points in time, e.g. a discharge window.
+type TimeRange struct {
+	Start time.Time
+	Stop  time.Time
+}
+
+// ParseTimeRange returns today's range between two schedule times accepted by ParseTime.
+// A stop time before the start time ends the range on the next day; equal times are rejected.
+func ParseTimeRange(start, stop string) (TimeRange, error) {
+	startTime, err := ParseTime(start)
+	if err != nil {
+		return TimeRange{}, fmt.Errorf("parsing start time: %w", err)
+	}
+	stopTime, err := ParseTime(stop)
+	if err != nil {
+		return TimeRange{}, fmt.Errorf("parsing stop time: %w", err)
+	}
+	if stopTime.Equal(startTime) {
+		return TimeRange{}, fmt.Errorf("empty time range %s-%s", start, stop)
+	}
+	if stopTime.Before(startTime) {
+		stopTime, err = NextDayTime(startTime, stop)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("parsing stop time: %w", err)
+		}
+	}
+	return TimeRange{Start: startTime, Stop: stopTime}, nil
+}
+
+// Contains tells whether t is within the range; the stop time is not included.
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.Stop)
+}
+
+func (r TimeRange) Duration() time.Duration {
+	return r.Stop.Sub(r.Start)
+}
+
+// IsOvernight tells whether the range ends on a later calendar day than it starts.
+func (r TimeRange) IsOvernight() bool {
+	y1, m1, d1 := r.Start.Date()
+	y2, m2, d2 := r.Stop.In(r.Start.Location()).Date()
+	return y1 != y2 || m1 != m2 || d1 != d2
+}
+
+// Overlaps tells whether the two ranges share any point in time.
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.Start.Before(other.Stop) && other.Start.Before(r.Stop)
+}
@@ -1,12 +1,101 @@
 package timer
 
-import "time"
+import (
+	"fmt"
+	"gok-pi/battery/solar/astro"
+	"sync"
+	"time"
+)
 
+const (
+	Sunrise = "sunrise"
+	Sunset  = "sunset"
+)
+
+var (
+	latitude       float64
+	longitude      float64
+	hasCoordinates bool
+	mutex          sync.RWMutex
+)
+
+// SetCoordinates sets the location used to resolve the "sunrise" and "sunset" keywords.
+func SetCoordinates(lat, lon float64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	latitude, longitude = lat, lon
+	hasCoordinates = true
+}
+
+// ParseTime returns today's time for a "15:04" string, or for the "sunrise" and "sunset" keywords
+// the astronomical time at the coordinates set with SetCoordinates.
 func ParseTime(timeStr string) (time.Time, error) {
-	now := time.Now()
+	return parseTimeOn(time.Now(), timeStr)
+}
+
+// NextDayTime returns the first occurrence of a "15:04" string, or of the "sunrise" and "sunset" keywords,
+// strictly after the given time, in its location: today's if still ahead, otherwise tomorrow's.
+func NextDayTime(after time.Time, hhmm string) (time.Time, error) {
+	t, err := parseTimeOn(after, hhmm)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.After(after) {
+		return t, nil
+	}
+	return parseTimeOn(after.AddDate(0, 0, 1), hhmm)
+}
+
+// parseTimeOn resolves a time string on the day of now.
+func parseTimeOn(now time.Time, timeStr string) (time.Time, error) {
+	if timeStr == Sunrise || timeStr == Sunset {
+		return solarTime(timeStr, now)
+	}
 	parsedTime, err := time.Parse("15:04", timeStr)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return time.Date(now.Year(), now.Month(), now.Day(), parsedTime.Hour(), parsedTime.Minute(), 0, 0, now.Location()), nil
+	return wallClock(now, parsedTime.Hour(), parsedTime.Minute()), nil
+}
+
+// wallClock returns the given wall clock time on the day of now, resolving DST transitions explicitly,
+// since time.Date does not specify which zone it picks for them: a time skipped by a spring-forward
+// transition is moved to the end of the gap, and a time repeated by a fall-back transition
+// resolves to its first occurrence.
+func wallClock(now time.Time, hour, minute int) time.Time {
+	t := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	start, end := t.ZoneBounds()
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	requested := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
+	switch {
+	case wall.After(requested) && !start.IsZero():
+		return start
+	case wall.Before(requested) && !end.IsZero():
+		return end
+	}
+	if start.IsZero() {
+		return t
+	}
+	_, offset := t.Zone()
+	_, previousOffset := start.Add(-time.Second).Zone()
+	if previousOffset > offset {
+		earlier := t.Add(-time.Duration(previousOffset-offset) * time.Second)
+		if earlier.Before(start) && earlier.Hour() == hour && earlier.Minute() == minute {
+			return earlier
+		}
+	}
+	return t
+}
+
+func solarTime(keyword string, now time.Time) (time.Time, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	if !hasCoordinates {
+		return time.Time{}, fmt.Errorf("%s requires coordinates to be configured", keyword)
+	}
+	sunrise, sunset := astro.SunriseSunset(latitude, longitude, now)
+	if keyword == Sunrise {
+		return sunrise.Truncate(time.Minute), nil
+	}
+	return sunset.Truncate(time.Minute), nil
 }
@@ -0,0 +1,37 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDayTimeYearBoundary(t *testing.T) {
+	for _, loc := range []*time.Location{time.UTC, time.FixedZone("CET", 3600)} {
+		after := time.Date(2024, time.December, 31, 23, 30, 0, 0, loc)
+		tests := []struct {
+			hhmm string
+			want time.Time
+		}{
+			// still ahead on the evening of Dec 31
+			{"23:45", time.Date(2024, time.December, 31, 23, 45, 0, 0, loc)},
+			{"23:30", time.Date(2025, time.January, 1, 23, 30, 0, 0, loc)},
+			{"23:15", time.Date(2025, time.January, 1, 23, 15, 0, 0, loc)},
+			{"00:00", time.Date(2025, time.January, 1, 0, 0, 0, 0, loc)},
+		}
+		for _, tt := range tests {
+			got, err := NextDayTime(after, tt.hhmm)
+			if err != nil {
+				t.Fatalf("%s after %s: %v", tt.hhmm, after, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("%s after %s: got %s, want %s", tt.hhmm, after, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestNextDayTimeInvalid(t *testing.T) {
+	if _, err := NextDayTime(time.Now(), "25:00"); err == nil {
+		t.Error("expected an error for 25:00")
+	}
+}
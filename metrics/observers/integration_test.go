@@ -0,0 +1,98 @@
+//go:build integration
+
+package observers_test
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gok-pi/battery/entity"
+	"gok-pi/metrics/observers"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScrape(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	for _, collector := range observers.Collectors() {
+		if err := registry.Register(collector); err != nil {
+			t.Fatalf("registering collector: %v", err)
+		}
+	}
+
+	const name = "scrape-test"
+	observers.UpdateAll(name, &entity.SystemStatus{
+		RSOC:                55,
+		USOC:                50,
+		RemainingCapacityWh: 5500,
+		ConsumptionW:        400,
+		PacTotalW:           1200,
+		BatteryDischarging:  true,
+	})
+	observers.AddGridExport(name, 100)
+	observers.AddCO2Saved(name, 0.5)
+	observers.AddSession(name, "soc_limit", 2500)
+	observers.UpdateInternalResistance(name, 0.08)
+	observers.UpdateMeterGridImport(name, 10)
+	observers.UpdateMeterGridExport(name, 20)
+	observers.UpdateMeterSelfConsumed(name, 30)
+	observers.UpdateMeterSolarGenerated(name, 40)
+	observers.UpdateDailyStats(name, &entity.DailyBatteryStats{
+		MinSoC:             20,
+		MaxSoC:             90,
+		CyclesStarted:      1,
+		EnergyChargedWh:    3000,
+		EnergyDischargedWh: 2500,
+	})
+	observers.AddClientError(name, "status")
+
+	server := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("scraping metrics: %v", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d, want 200", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading metrics: %v", err)
+	}
+
+	label := `name="` + name + `"`
+	for _, series := range []string{
+		`battery_RSoC{` + label + `} 55`,
+		`battery_USoC{` + label + `} 50`,
+		`battery_RemainingCapacity_W{` + label + `} 5500`,
+		`battery_Consumption_W{` + label + `} 400`,
+		`battery_Pac_total_W{` + label + `} 1200`,
+		`battery_Status{` + label + `,status="discharging"} 1`,
+		`battery_Status{` + label + `,status="idle"} 0`,
+		`battery_GridExport_Wh{` + label + `} 100`,
+		`battery_CO2Saved_kg{` + label + `} 0.5`,
+		`battery_Sessions_total{` + label + `,stop_reason="soc_limit"} 1`,
+		`battery_SessionEnergy_Wh{` + label + `} 2500`,
+		`battery_InternalResistance_Ohm{` + label + `} 0.08`,
+		`battery_MeterGridImport_kWh{` + label + `} 10`,
+		`battery_MeterGridExport_kWh{` + label + `} 20`,
+		`battery_MeterSelfConsumed_kWh{` + label + `} 30`,
+		`battery_MeterSolarGenerated_kWh{` + label + `} 40`,
+		`battery_DailyMinSoC{` + label + `} 20`,
+		`battery_DailyMaxSoC{` + label + `} 90`,
+		`battery_DailyCycles{` + label + `} 1`,
+		`battery_DailyCharged_Wh{` + label + `} 3000`,
+		`battery_DailyDischarged_Wh{` + label + `} 2500`,
+		`battery_ClientErrors_total{call="status",` + label + `} 1`,
+	} {
+		if !strings.Contains(string(body), series+"\n") {
+			t.Errorf("series %s not found", series)
+		}
+	}
+}
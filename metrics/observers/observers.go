@@ -3,8 +3,19 @@ package observers
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gok-pi/battery/entity"
 )
 
+// UpdateAll sets all gauges derived from a battery status reading.
+func UpdateAll(name string, status *entity.SystemStatus) {
+	UpdateSoC(name, status.RSOC)
+	UpdateUSoC(name, status.USOC)
+	UpdateCapacity(name, status.RemainingCapacityWh)
+	UpdateConsumption(name, status.ConsumptionW)
+	UpdatePac(name, status.PacTotalW)
+	UpdateBatteryStatus(name, status.BatteryStatus())
+}
+
 var socGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Namespace: "battery",
 	Name:      "RSoC",
@@ -55,16 +66,182 @@ func UpdatePac(name string, value float64) {
 	pacGauge.WithLabelValues(name).Set(value)
 }
 
-var dischargeStateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+var batteryStatusGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "Status",
+	Help:      "Battery status: 1 for the active status, 0 for all others",
+}, []string{"name", "status"})
+
+func UpdateBatteryStatus(name string, status entity.BatteryStatus) {
+	for _, s := range entity.BatteryStatuses {
+		if s == status {
+			batteryStatusGauge.WithLabelValues(name, s.String()).Set(1.0)
+		} else {
+			batteryStatusGauge.WithLabelValues(name, s.String()).Set(0.0)
+		}
+	}
+}
+
+var gridExportCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "battery",
+	Name:      "GridExport_Wh",
+	Help:      "Energy exported to the grid during discharge sessions in Watt-hours",
+}, []string{"name"})
+
+func AddGridExport(name string, wh float64) {
+	gridExportCounter.WithLabelValues(name).Add(wh)
+}
+
+var co2SavedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "battery",
+	Name:      "CO2Saved_kg",
+	Help:      "CO2 emissions avoided by discharging instead of importing from the grid in kilograms",
+}, []string{"name"})
+
+func AddCO2Saved(name string, kg float64) {
+	co2SavedCounter.WithLabelValues(name).Add(kg)
+}
+
+var sessionCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "battery",
+	Name:      "Sessions_total",
+	Help:      "Completed discharge sessions, by stop reason",
+}, []string{"name", "stop_reason"})
+
+var sessionEnergyCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "battery",
+	Name:      "SessionEnergy_Wh",
+	Help:      "Energy delivered by the battery during discharge sessions in Watt-hours",
+}, []string{"name"})
+
+func AddSession(name, stopReason string, wh float64) {
+	sessionCounter.WithLabelValues(name, stopReason).Inc()
+	sessionEnergyCounter.WithLabelValues(name).Add(wh)
+}
+
+var internalResistanceGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "InternalResistance_Ohm",
+	Help:      "Internal resistance of the battery in Ohms, tracked for second-life modules",
+}, []string{"name"})
+
+func UpdateInternalResistance(name string, ohm float64) {
+	internalResistanceGauge.WithLabelValues(name).Set(ohm)
+}
+
+var gridImportGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "MeterGridImport_kWh",
+	Help:      "Energy imported from the grid in kWh, as counted by the inverter meter",
+}, []string{"name"})
+
+func UpdateMeterGridImport(name string, value float64) {
+	gridImportGauge.WithLabelValues(name).Set(value)
+}
+
+var gridExportMeterGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "MeterGridExport_kWh",
+	Help:      "Energy exported to the grid in kWh, as counted by the inverter meter",
+}, []string{"name"})
+
+func UpdateMeterGridExport(name string, value float64) {
+	gridExportMeterGauge.WithLabelValues(name).Set(value)
+}
+
+var selfConsumedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "MeterSelfConsumed_kWh",
+	Help:      "Solar energy consumed on site in kWh",
+}, []string{"name"})
+
+func UpdateMeterSelfConsumed(name string, value float64) {
+	selfConsumedGauge.WithLabelValues(name).Set(value)
+}
+
+var solarGeneratedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "MeterSolarGenerated_kWh",
+	Help:      "Solar energy generated in kWh, as counted by the inverter meter",
+}, []string{"name"})
+
+func UpdateMeterSolarGenerated(name string, value float64) {
+	solarGeneratedGauge.WithLabelValues(name).Set(value)
+}
+
+var dailyMinSoCGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "DailyMinSoC",
+	Help:      "Minimum state of charge of the current day in percent",
+}, []string{"name"})
+
+var dailyMaxSoCGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "DailyMaxSoC",
+	Help:      "Maximum state of charge of the current day in percent",
+}, []string{"name"})
+
+var dailyCyclesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Namespace: "battery",
-	Name:      "BatteryDischarging",
-	Help:      "Discharge status: 1 - discharging, 0 - not discharging",
+	Name:      "DailyCycles",
+	Help:      "Discharge cycles started during the current day",
 }, []string{"name"})
 
-func UpdateDischargeState(name string, state bool) {
-	if state {
-		dischargeStateGauge.WithLabelValues(name).Set(1.0)
-	} else {
-		dischargeStateGauge.WithLabelValues(name).Set(0.0)
+var dailyChargedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "DailyCharged_Wh",
+	Help:      "Energy charged into the battery during the current day in Wh",
+}, []string{"name"})
+
+var dailyDischargedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "battery",
+	Name:      "DailyDischarged_Wh",
+	Help:      "Energy discharged from the battery during the current day in Wh",
+}, []string{"name"})
+
+func UpdateDailyStats(name string, stats *entity.DailyBatteryStats) {
+	dailyMinSoCGauge.WithLabelValues(name).Set(float64(stats.MinSoC))
+	dailyMaxSoCGauge.WithLabelValues(name).Set(float64(stats.MaxSoC))
+	dailyCyclesGauge.WithLabelValues(name).Set(float64(stats.CyclesStarted))
+	dailyChargedGauge.WithLabelValues(name).Set(stats.EnergyChargedWh)
+	dailyDischargedGauge.WithLabelValues(name).Set(stats.EnergyDischargedWh)
+}
+
+var clientErrorCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "battery",
+	Name:      "ClientErrors_total",
+	Help:      "Failed calls to the battery API, by call",
+}, []string{"name", "call"})
+
+func AddClientError(name, call string) {
+	clientErrorCounter.WithLabelValues(name, call).Inc()
+}
+
+// batteryVectors lists every metric vector labelled by battery name.
+var batteryVectors = []interface {
+	prometheus.Collector
+	DeletePartialMatch(labels prometheus.Labels) int
+}{
+	socGauge, uSocGauge, capacityGauge, consumptionGauge, pacGauge, batteryStatusGauge,
+	gridExportCounter, co2SavedCounter, sessionCounter, sessionEnergyCounter, internalResistanceGauge,
+	gridImportGauge, gridExportMeterGauge, selfConsumedGauge, solarGeneratedGauge,
+	dailyMinSoCGauge, dailyMaxSoCGauge, dailyCyclesGauge, dailyChargedGauge, dailyDischargedGauge,
+	clientErrorCounter,
+}
+
+// Unregister deletes all series of a battery removed from the fleet, so they do not linger as stale series.
+func Unregister(name string) {
+	for _, vector := range batteryVectors {
+		vector.DeletePartialMatch(prometheus.Labels{"name": name})
+	}
+}
+
+// Collectors returns all metric vectors, which are registered with the default registry, so they can
+// also be served from another registry, e.g. in tests.
+func Collectors() []prometheus.Collector {
+	collectors := make([]prometheus.Collector, 0, len(batteryVectors))
+	for _, vector := range batteryVectors {
+		collectors = append(collectors, vector)
 	}
+	return collectors
 }
@@ -0,0 +1,75 @@
+//go:build otel
+
+// Package otel mirrors the observers package for deployments that export metrics
+// with the OpenTelemetry SDK instead of Prometheus. Instruments are created on the
+// global meter provider, so the application must install its own provider at startup.
+// The package is only built with the "otel" build tag.
+package otel
+
+import (
+	"context"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gok-pi/battery/entity"
+)
+
+var meter = otelapi.Meter("gok-pi/metrics")
+
+var socGauge = mustGauge("battery.RSoC", "Relative state of charge in percent", "%")
+
+func UpdateSoC(name string, value float64) {
+	record(socGauge, name, value)
+}
+
+var uSocGauge = mustGauge("battery.USoC", "User state of charge in percent", "%")
+
+func UpdateUSoC(name string, value float64) {
+	record(uSocGauge, name, value)
+}
+
+var capacityGauge = mustGauge("battery.RemainingCapacity_W", "Remaining capacity based on RSoC", "Wh")
+
+func UpdateCapacity(name string, value float64) {
+	record(capacityGauge, name, value)
+}
+
+var consumptionGauge = mustGauge("battery.Consumption_W", "House consumption in Watts, direct measurement", "W")
+
+func UpdateConsumption(name string, value float64) {
+	record(consumptionGauge, name, value)
+}
+
+var pacGauge = mustGauge("battery.Pac_total_W", "AC Power: greater than zero - discharging, less than zero - charging in Watts", "W")
+
+func UpdatePac(name string, value float64) {
+	record(pacGauge, name, value)
+}
+
+var batteryStatusGauge = mustGauge("battery.Status", "Battery status: 1 for the active status, 0 for all others", "")
+
+func UpdateBatteryStatus(name string, status entity.BatteryStatus) {
+	for _, s := range entity.BatteryStatuses {
+		value := 0.0
+		if s == status {
+			value = 1.0
+		}
+		batteryStatusGauge.Record(context.Background(), value, metric.WithAttributes(
+			attribute.String("name", name),
+			attribute.String("status", s.String()),
+		))
+	}
+}
+
+func record(gauge metric.Float64Gauge, name string, value float64) {
+	gauge.Record(context.Background(), value, metric.WithAttributes(attribute.String("name", name)))
+}
+
+// mustGauge creates a gauge on the package meter and panics on failure, same as promauto does.
+func mustGauge(name, description, unit string) metric.Float64Gauge {
+	gauge, err := meter.Float64Gauge(name, metric.WithDescription(description), metric.WithUnit(unit))
+	if err != nil {
+		panic(err)
+	}
+	return gauge
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"gok-pi/battery/api"
+	"os"
+	"regexp"
+	"runtime/debug"
+)
+
+// gen-client generates the Go client of the battery API in battery/api/client from the OpenAPI
+// specification of all endpoints, with oapi-codegen. The generated file is checked in; run with -check
+// in CI to fail when it is out of sync with the specification.
+
+const codegenModule = "github.com/oapi-codegen/oapi-codegen/v2"
+
+// generatedBy matches the header written by oapi-codegen, which names the version of the main module;
+// it is replaced with the oapi-codegen version, so the output does not depend on the checkout.
+var generatedBy = regexp.MustCompile(`(?m)^// Code generated by .* DO NOT EDIT\.$`)
+
+func main() {
+	out := flag.String("out", "battery/api/client/client.gen.go", "path of the generated client file")
+	check := flag.Bool("check", false, "fail if the generated file differs from the current specification")
+	flag.Parse()
+
+	code, err := generate()
+	if err != nil {
+		fail("generating client: %v", err)
+	}
+
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil {
+			fail("reading client: %v", err)
+		}
+		if !bytes.Equal(current, code) {
+			fail("%s is out of sync with the specification; run go generate ./battery/api/client", *out)
+		}
+		fmt.Printf("%s is up to date\n", *out)
+		return
+	}
+	if err = os.WriteFile(*out, code, 0o644); err != nil {
+		fail("writing client: %v", err)
+	}
+	fmt.Printf("%s generated\n", *out)
+}
+
+func generate() ([]byte, error) {
+	doc, err := api.Spec()
+	if err != nil {
+		return nil, fmt.Errorf("specification: %w", err)
+	}
+	code, err := codegen.Generate(doc, codegen.Configuration{
+		PackageName: "client",
+		Generate: codegen.GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: codegen.OutputOptions{
+			InitialismOverrides: true,
+			// the default suffix, Response, clashes with response schemas such as ScheduleApplianceResponse
+			ResponseTypeSuffix: "Result",
+		},
+	}.UpdateDefaults())
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("// Code generated by oapi-codegen %s from the OpenAPI specification. DO NOT EDIT.", codegenVersion())
+	return generatedBy.ReplaceAll([]byte(code), []byte(header)), nil
+}
+
+// codegenVersion returns the version of oapi-codegen that gen-client is built with.
+func codegenVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == codegenModule {
+				return dep.Version
+			}
+		}
+	}
+	return "(unknown version)"
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
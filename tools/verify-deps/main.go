@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// verify-deps checks module dependencies before a build: it runs `go mod verify` to confirm
+// the module cache matches go.sum, then compares every go.sum entry with the reviewed checksums
+// in deps.lock. A changed checksum or a dependency that was never reviewed fails the check.
+// After reviewing a dependency change, regenerate the lock file with -update.
+
+func main() {
+
+	sumPath := flag.String("sum", "go.sum", "path to go.sum file")
+	lockPath := flag.String("lock", "deps.lock", "path to lock file with known-good checksums")
+	update := flag.Bool("update", false, "write current go.sum checksums to the lock file")
+	flag.Parse()
+
+	sums, err := readSum(*sumPath)
+	if err != nil {
+		fail("reading go.sum: %v", err)
+	}
+
+	if *update {
+		if err = writeLock(*lockPath, sums); err != nil {
+			fail("writing lock file: %v", err)
+		}
+		fmt.Printf("%s updated: %d checksums\n", *lockPath, len(sums))
+		return
+	}
+
+	cmd := exec.Command("go", "mod", "verify")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		fail("go mod verify: %v", err)
+	}
+
+	lock, err := readLock(*lockPath)
+	if err != nil {
+		fail("reading lock file: %v", err)
+	}
+
+	problems := compare(sums, lock)
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		fail("%d dependency checksums do not match %s", len(problems), *lockPath)
+	}
+	fmt.Printf("all %d checksums match %s\n", len(sums), *lockPath)
+}
+
+// readSum parses go.sum into a map of "module version" to hash.
+func readSum(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: malformed entry", line)
+		}
+		sums[fields[0]+" "+fields[1]] = fields[2]
+	}
+	return sums, scanner.Err()
+}
+
+func readLock(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lock map[string]string
+	if err = json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+func writeLock(path string, sums map[string]string) error {
+	data, err := json.MarshalIndent(sums, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// compare returns a description of every go.sum entry that is missing from the lock or has a different hash.
+func compare(sums, lock map[string]string) []string {
+	var problems []string
+	for module, hash := range sums {
+		known, ok := lock[module]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("not reviewed: %s %s", module, hash))
+		case known != hash:
+			problems = append(problems, fmt.Sprintf("checksum mismatch: %s: lock %s, go.sum %s", module, known, hash))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "verify-deps: "+format+"\n", args...)
+	os.Exit(1)
+}